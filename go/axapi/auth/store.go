@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNoStoredToken is returned by TokenStore.Load when nothing is stored
+// for the app
+var ErrNoStoredToken = errors.New("auth: no stored token")
+
+// errCorruptFile marks a token file that does not hold valid JSON
+var errCorruptFile = errors.New("auth: corrupt token file")
+
+// StoredToken is a token together with the data needed to judge its validity
+type StoredToken struct {
+	Token string `json:"token"`
+	Key   string `json:"key"`
+	// Timestamp is when the token was requested, in Unix milliseconds
+	Timestamp int64 `json:"timestamp"`
+	// ExpireTime is the token lifetime in seconds
+	ExpireTime int64 `json:"expireTime"`
+}
+
+// Expiry returns the time at which the token stops being accepted
+func (st StoredToken) Expiry() time.Time {
+	return time.UnixMilli(st.Timestamp).Add(time.Duration(st.ExpireTime) * time.Second)
+}
+
+// TokenStore persists tokens so they survive restarts and can be shared
+// between processes. Tokens are keyed by APPID.
+type TokenStore interface {
+	Load(ctx context.Context, appID string) (StoredToken, error)
+	Save(ctx context.Context, appID string, token StoredToken) error
+}
+
+// StoreFuncs adapts a pair of functions to a TokenStore, which is the
+// simplest way to plug in a shared store such as Redis or a database
+type StoreFuncs struct {
+	LoadFunc func(ctx context.Context, appID string) (StoredToken, error)
+	SaveFunc func(ctx context.Context, appID string, token StoredToken) error
+}
+
+// Load calls f.LoadFunc
+func (f StoreFuncs) Load(ctx context.Context, appID string) (StoredToken, error) {
+	return f.LoadFunc(ctx, appID)
+}
+
+// Save calls f.SaveFunc
+func (f StoreFuncs) Save(ctx context.Context, appID string, token StoredToken) error {
+	return f.SaveFunc(ctx, appID, token)
+}
+
+// MemoryStore keeps tokens in memory, e.g. to share one token between
+// several TokenManagers in the same process
+type MemoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]StoredToken
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: map[string]StoredToken{}}
+}
+
+// Load returns the token stored for appID
+func (ms *MemoryStore) Load(ctx context.Context, appID string) (StoredToken, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	token, ok := ms.tokens[appID]
+	if !ok {
+		return StoredToken{}, ErrNoStoredToken
+	}
+	return token, nil
+}
+
+// Save stores token for appID
+func (ms *MemoryStore) Save(ctx context.Context, appID string, token StoredToken) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.tokens[appID] = token
+	return nil
+}
+
+// FileStore keeps tokens in a JSON file readable only by the current user.
+// Writes replace the file atomically, so several processes may share it.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a FileStore backed by path.
+// The file is created on the first Save.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load returns the token stored for appID
+func (fs *FileStore) Load(ctx context.Context, appID string) (StoredToken, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	tokens, err := fs.read()
+	if err != nil {
+		return StoredToken{}, err
+	}
+	token, ok := tokens[appID]
+	if !ok {
+		return StoredToken{}, ErrNoStoredToken
+	}
+	return token, nil
+}
+
+// Save stores token for appID, keeping the tokens of other apps.
+// A corrupt file is overwritten.
+func (fs *FileStore) Save(ctx context.Context, appID string, token StoredToken) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	tokens, err := fs.read()
+	if errors.Is(err, errCorruptFile) {
+		tokens, err = map[string]StoredToken{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	tokens[appID] = token
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fs.path), filepath.Base(fs.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), fs.path)
+}
+
+// read loads all tokens in the file; a missing file holds no tokens
+func (fs *FileStore) read() (map[string]StoredToken, error) {
+	tokens := map[string]StoredToken{}
+	data, err := os.ReadFile(fs.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("%w %s: %w", errCorruptFile, fs.path, err)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	store := NewFileStore(path)
+
+	if _, err := store.Load(ctx, "app1"); !errors.Is(err, ErrNoStoredToken) {
+		t.Fatalf("Load() on missing file error = %v, want ErrNoStoredToken", err)
+	}
+
+	tok1 := StoredToken{Token: "t1", Key: "k1", Timestamp: 1000, ExpireTime: 3600}
+	tok2 := StoredToken{Token: "t2", Key: "k2", Timestamp: 2000, ExpireTime: 7200}
+	if err := store.Save(ctx, "app1", tok1); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := NewFileStore(path).Save(ctx, "app2", tok2); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	for appID, want := range map[string]StoredToken{"app1": tok1, "app2": tok2} {
+		got, err := NewFileStore(path).Load(ctx, appID)
+		if err != nil || got != want {
+			t.Errorf("Load(%q) = %+v, %v, want %+v", appID, got, err, want)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("file mode = %v, want 0600", perm)
+	}
+}
+
+func TestFileStore_Corrupt(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store := NewFileStore(path)
+
+	if _, err := store.Load(ctx, "app1"); err == nil || errors.Is(err, ErrNoStoredToken) {
+		t.Errorf("Load() on corrupt file error = %v, want a parse error", err)
+	}
+	tok := StoredToken{Token: "t1", Timestamp: 1000, ExpireTime: 3600}
+	if err := store.Save(ctx, "app1", tok); err != nil {
+		t.Fatalf("Save() over corrupt file error = %v", err)
+	}
+	if got, err := store.Load(ctx, "app1"); err != nil || got != tok {
+		t.Errorf("Load() = %+v, %v, want %+v", got, err, tok)
+	}
+}
+
+func TestTokenManager_StoreErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":200,"data":{"key":"k","token":"fresh","expireTime":3600}}`))
+	}))
+	defer srv.Close()
+
+	errBroken := errors.New("store unavailable")
+	store := StoreFuncs{
+		LoadFunc: func(ctx context.Context, appID string) (StoredToken, error) {
+			return StoredToken{}, errBroken
+		},
+		SaveFunc: func(ctx context.Context, appID string, token StoredToken) error {
+			return errBroken
+		},
+	}
+	var errs []error
+	client := axapi.NewClient(&axapi.Config{URLPrefix: srv.URL, APPID: "app"})
+	manager := NewTokenManager(client, WithTokenStore(store), WithStoreErrorHandler(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	// the server is asked instead and both failures are reported
+	if token, err := manager.GetToken(context.Background()); err != nil || token != "fresh" {
+		t.Fatalf("GetToken() = %q, %v, want fresh token", token, err)
+	}
+	if len(errs) != 2 || !errors.Is(errs[0], errBroken) || !errors.Is(errs[1], errBroken) {
+		t.Errorf("store errors = %v, want the load and save failures", errs)
+	}
+}
+
+func TestTokenManager_TokenStore(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status":200,"data":{"key":"k","token":"fresh","expireTime":3600}}`))
+	}))
+	defer srv.Close()
+
+	client := axapi.NewClient(&axapi.Config{URLPrefix: srv.URL, APPID: "app"})
+	store := NewMemoryStore()
+	store.Save(ctx, "app", StoredToken{Token: "stored", Timestamp: time.Now().UnixMilli(), ExpireTime: 3600})
+
+	manager := NewTokenManager(client, WithTokenStore(store))
+	if token, err := manager.GetToken(ctx); err != nil || token != "stored" || calls != 0 {
+		t.Fatalf("GetToken() = %q, %v after %d calls, want stored token without server call", token, err, calls)
+	}
+
+	// a token rejected by the server is not reloaded from the store
+	manager.Invalidate()
+	if token, err := manager.GetToken(ctx); err != nil || token != "fresh" || calls != 1 {
+		t.Fatalf("GetToken() = %q, %v after %d calls, want fresh token from server", token, err, calls)
+	}
+	if saved, _ := store.Load(ctx, "app"); saved.Token != "fresh" {
+		t.Errorf("stored token = %q, want %q", saved.Token, "fresh")
+	}
+
+	// a second manager, e.g. another replica, picks up the saved token
+	other := NewTokenManager(client, WithTokenStore(store))
+	if token, _ := other.GetToken(ctx); token != "fresh" || calls != 1 {
+		t.Errorf("GetToken() = %q after %d calls, want shared fresh token", token, calls)
+	}
+}
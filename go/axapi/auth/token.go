@@ -0,0 +1,246 @@
+// Package auth obtains and caches access tokens for the Autoxing API.
+package auth
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// TokenData represents the data field of the token response
+type TokenData struct {
+	Key        string `json:"key"`
+	Token      string `json:"token"`
+	ExpireTime int64  `json:"expireTime"`
+}
+
+// NewClient creates an axapi.Client for config whose requests are
+// authenticated by a TokenManager, so tokens are fetched and renewed
+// transparently
+func NewClient(config *axapi.Config, opts ...axapi.Option) *axapi.Client {
+	client := axapi.NewClient(config, opts...)
+	return client.WithTokenSource(NewTokenManager(client))
+}
+
+// DefaultRefreshSkew is how long before expireTime a token is renewed
+const DefaultRefreshSkew = time.Minute
+
+// TokenManager handles token operations.
+// It is safe for concurrent use; concurrent refreshes are coalesced into a
+// single call to the token endpoint.
+type TokenManager struct {
+	client *axapi.Client
+	skew   time.Duration
+	store  TokenStore
+	// onStoreError receives the errors of store; may be nil
+	onStoreError func(error)
+
+	mu         sync.Mutex
+	current    StoredToken
+	ok         bool
+	rejected   string
+	refreshing *refreshCall
+}
+
+// refreshCall is an in-flight token request shared by all waiting callers
+type refreshCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// Option configures a TokenManager
+type Option func(*TokenManager)
+
+// WithRefreshSkew renews the token d before it expires instead of
+// DefaultRefreshSkew. The skew never exceeds half of the token lifetime.
+func WithRefreshSkew(d time.Duration) Option {
+	return func(tm *TokenManager) {
+		tm.skew = d
+	}
+}
+
+// WithTokenStore makes the TokenManager reuse tokens persisted in store
+// before asking the server, and persist every token it obtains.
+// Store errors are not fatal: the server is asked instead. Use
+// WithStoreErrorHandler to be told about them.
+func WithTokenStore(store TokenStore) Option {
+	return func(tm *TokenManager) {
+		tm.store = store
+	}
+}
+
+// WithStoreErrorHandler calls handle with every error of the token store
+// other than ErrNoStoredToken, e.g. to log that tokens are not being
+// persisted. handle runs on the refresh and should not block for long.
+func WithStoreErrorHandler(handle func(err error)) Option {
+	return func(tm *TokenManager) {
+		tm.onStoreError = handle
+	}
+}
+
+// NewTokenManager creates a new instance of TokenManager.
+// The APPID, APPSecret and Authorization are taken from the client's Config.
+func NewTokenManager(client *axapi.Client, opts ...Option) *TokenManager {
+	tm := &TokenManager{
+		client: client,
+		skew:   DefaultRefreshSkew,
+		ok:     false,
+	}
+	for _, opt := range opts {
+		opt(tm)
+	}
+	return tm
+}
+
+// GetToken retrieves a valid token, fetching a new one when the cached
+// token is missing or about to expire
+func (tm *TokenManager) GetToken(ctx context.Context) (string, error) {
+	tm.mu.Lock()
+	if tm.ok && tm.valid(tm.current, time.Now()) {
+		token := tm.current.Token
+		tm.mu.Unlock()
+		return token, nil
+	}
+	call := tm.refreshing
+	if call == nil {
+		call = &refreshCall{done: make(chan struct{})}
+		tm.refreshing = call
+		// The refresh outlives the caller that started it, so one caller
+		// giving up does not fail everybody else waiting on it.
+		go tm.refresh(context.WithoutCancel(ctx), call)
+	}
+	tm.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// valid reports whether token can still be used at now, renewing it
+// tm.skew ahead of its expiry
+func (tm *TokenManager) valid(token StoredToken, now time.Time) bool {
+	lifetime := time.Duration(token.ExpireTime) * time.Second
+	skew := tm.skew
+	if skew > lifetime/2 {
+		skew = lifetime / 2
+	}
+	return now.Before(token.Expiry().Add(-skew))
+}
+
+// refresh obtains a token from the store or the server and publishes the
+// result to call
+func (tm *TokenManager) refresh(ctx context.Context, call *refreshCall) {
+	token, err := tm.loadOrFetch(ctx)
+
+	tm.mu.Lock()
+	if err != nil {
+		tm.ok = false
+	} else {
+		tm.current = token
+		tm.ok = true
+	}
+	tm.refreshing = nil
+	tm.mu.Unlock()
+
+	call.token, call.err = token.Token, err
+	close(call.done)
+}
+
+// loadOrFetch returns a valid token from the store if there is one,
+// otherwise a new token from the server, which is then saved to the store
+func (tm *TokenManager) loadOrFetch(ctx context.Context) (StoredToken, error) {
+	appID := tm.client.Config().APPID
+
+	if tm.store != nil {
+		token, err := tm.store.Load(ctx, appID)
+		tm.mu.Lock()
+		rejected := tm.rejected
+		tm.mu.Unlock()
+		if err == nil && token.Token != rejected && tm.valid(token, time.Now()) {
+			return token, nil
+		}
+		if err != nil && !errors.Is(err, ErrNoStoredToken) {
+			tm.storeError(fmt.Errorf("loading token: %w", err))
+		}
+	}
+
+	timestamp := time.Now().UnixMilli()
+	data, err := tm.getTokenFromServer(ctx, timestamp)
+	if err != nil {
+		return StoredToken{}, err
+	}
+	token := StoredToken{
+		Token:      data.Token,
+		Key:        data.Key,
+		Timestamp:  timestamp,
+		ExpireTime: data.ExpireTime,
+	}
+
+	if tm.store != nil {
+		if err := tm.store.Save(ctx, appID, token); err != nil {
+			tm.storeError(fmt.Errorf("saving token: %w", err))
+		}
+	}
+	return token, nil
+}
+
+// storeError reports a store error to the handler, if any
+func (tm *TokenManager) storeError(err error) {
+	if tm.onStoreError != nil {
+		tm.onStoreError(err)
+	}
+}
+
+// Token implements axapi.TokenSource, refreshing the token before it expires
+func (tm *TokenManager) Token(ctx context.Context) (string, error) {
+	return tm.GetToken(ctx)
+}
+
+// Invalidate discards the cached token so the next call fetches a new one.
+// The client calls it when the server rejects the token.
+func (tm *TokenManager) Invalidate() {
+	tm.mu.Lock()
+	if tm.ok {
+		tm.rejected = tm.current.Token
+	}
+	tm.ok = false
+	tm.mu.Unlock()
+}
+
+// getTokenFromServer fetches a new token from the server
+func (tm *TokenManager) getTokenFromServer(ctx context.Context, timestamp int64) (TokenData, error) {
+	config := tm.client.Config()
+
+	// Calculate sign
+	signStr := fmt.Sprintf("%s%d%s", config.APPID, timestamp, config.APPSecret)
+	hasher := md5.New()
+	hasher.Write([]byte(signStr))
+
+	req := &axapi.Request{
+		Method: http.MethodPost,
+		Path:   "/auth/v1.1/token",
+		Body: map[string]interface{}{
+			"appId":     config.APPID,
+			"timestamp": timestamp,
+			"sign":      hex.EncodeToString(hasher.Sum(nil)),
+		},
+		Header:     http.Header{"Authorization": {config.Authorization}},
+		NoAuth:     true,
+		Idempotent: true,
+	}
+
+	var data TokenData
+	err := tm.client.Do(ctx, req, &data)
+	return data, err
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestAxToken(t *testing.T) {
+	// Example configuration
+	config, err := axapi.LoadConfig("")
+	if err != nil {
+		t.Skip("API credentials not configured:", err)
+	}
+
+	manager := NewTokenManager(axapi.NewClient(config))
+	token, err := manager.GetToken(context.Background())
+	fmt.Printf("Error: %v, Token: %s\n", err, token)
+	if err == nil {
+		t.Log("TestAxToken passed")
+		return
+	}
+	t.Error("TestAxToken failed:", err)
+}
+
+func TestTokenManager_GetToken(t *testing.T) {
+	calls := 0
+	var gotAuth string
+	var gotBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		gotAuth = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		fmt.Fprintf(w, `{"status":200,"data":{"key":"k","token":"tok%d","expireTime":3600}}`, calls)
+	}))
+	defer srv.Close()
+
+	config := &axapi.Config{
+		URLPrefix:     srv.URL,
+		APPID:         "app",
+		APPSecret:     "secret",
+		Authorization: "APPCODE code",
+	}
+	manager := NewTokenManager(axapi.NewClient(config))
+
+	token, err := manager.GetToken(context.Background())
+	if err != nil || token != "tok1" {
+		t.Fatalf("GetToken() = %q, %v, want tok1", token, err)
+	}
+	if gotAuth != "APPCODE code" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "APPCODE code")
+	}
+	timestamp := int64(gotBody["timestamp"].(float64))
+	sum := md5.Sum([]byte(fmt.Sprintf("app%dsecret", timestamp)))
+	if gotBody["sign"] != hex.EncodeToString(sum[:]) {
+		t.Errorf("sign = %v, want md5(appId+timestamp+secret)", gotBody["sign"])
+	}
+
+	// cached until invalidated
+	if token, _ := manager.Token(context.Background()); token != "tok1" || calls != 1 {
+		t.Errorf("Token() = %q after %d calls, want cached tok1", token, calls)
+	}
+	manager.Invalidate()
+	if token, _ := manager.Token(context.Background()); token != "tok2" || calls != 2 {
+		t.Errorf("Token() = %q after %d calls, want refreshed tok2", token, calls)
+	}
+}
+
+func TestTokenManager_ConcurrentRefresh(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Write([]byte(`{"status":200,"data":{"token":"tok","expireTime":3600}}`))
+	}))
+	defer srv.Close()
+
+	manager := NewTokenManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := manager.GetToken(context.Background())
+			if err == nil && token != "tok" {
+				err = fmt.Errorf("token = %q", token)
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetToken() error = %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("token endpoint called %d times, want 1", n)
+	}
+}
+
+func TestTokenManager_RefreshSkew(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		skew       time.Duration
+		expireTime int64
+		age        time.Duration
+		want       bool
+	}{
+		{name: "fresh", skew: time.Minute, expireTime: 3600, age: time.Minute, want: true},
+		{name: "inside skew", skew: time.Minute, expireTime: 3600, age: 3590 * time.Second, want: false},
+		{name: "expired", skew: 0, expireTime: 3600, age: 2 * time.Hour, want: false},
+		{name: "skew capped at half lifetime", skew: time.Hour, expireTime: 60, age: 20 * time.Second, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := &TokenManager{skew: tt.skew}
+			token := StoredToken{
+				ExpireTime: tt.expireTime,
+				Timestamp:  now.Add(-tt.age).UnixMilli(),
+			}
+			if got := tm.valid(token, now); got != tt.want {
+				t.Errorf("valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
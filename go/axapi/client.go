@@ -0,0 +1,261 @@
+package axapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the per-request timeout applied when the caller's
+// context carries no deadline of its own
+const DefaultTimeout = 5 * time.Second
+
+// TokenSource supplies the X-Token header for authenticated requests
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// RefreshableTokenSource is a TokenSource that can discard its cached token,
+// forcing the next call to Token to fetch a new one
+type RefreshableTokenSource interface {
+	TokenSource
+	Invalidate()
+}
+
+// StaticToken is a TokenSource that always returns the same token
+type StaticToken string
+
+// Token returns the token itself
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// Envelope is the common wrapper around every API response body
+type Envelope struct {
+	Status  int             `json:"status"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Request describes a single API call
+type Request struct {
+	Method string
+	// Path is appended to the client's base URL, e.g. "/robot/v1.1/list"
+	Path string
+	// Body is marshaled as JSON when non-nil
+	Body   interface{}
+	Header http.Header
+	// NoAuth skips the X-Token header, used by the token endpoint itself
+	NoAuth bool
+	// Idempotent marks a request that is safe to retry even though it is
+	// not a GET, e.g. a POST that only queries data
+	Idempotent bool
+}
+
+// Client owns the transport, base URL and token source shared by all services
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+	timeout    time.Duration
+	retry      RetryPolicy
+	limits     *rateLimits
+	baseURL    string
+	tokens     TokenSource
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient replaces the underlying http.Client
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout sets the per-request timeout used for contexts without a
+// deadline. Zero disables it.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithTokenSource sets the source of the X-Token header
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = ts
+	}
+}
+
+// NewClient creates a new client for config.URLPrefix
+func NewClient(config *Config, opts ...Option) *Client {
+	c := &Client{
+		config:  config,
+		timeout: DefaultTimeout,
+		retry:   DefaultRetryPolicy,
+		limits:  &rateLimits{},
+		baseURL: config.URLPrefix,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{}
+	}
+	return c
+}
+
+// Config returns the configuration the client was created with
+func (c *Client) Config() *Config {
+	return c.config
+}
+
+// BaseURL returns the URL prefix every request path is appended to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// TokenSource returns the source of the X-Token header, or nil
+func (c *Client) TokenSource() TokenSource {
+	return c.tokens
+}
+
+// WithTokenSource returns a copy of the client that authenticates with ts.
+// The copy shares the underlying transport and rate limiters.
+func (c *Client) WithTokenSource(ts TokenSource) *Client {
+	cc := *c
+	cc.tokens = ts
+	return &cc
+}
+
+// Do sends req and decodes the data field of the response envelope into out.
+// out may be nil when the caller only needs the call to succeed.
+// If ctx has no deadline the client's default timeout is applied.
+//
+// Failed attempts are retried according to the client's RetryPolicy.
+// When the server rejects the token and the token source is a
+// RefreshableTokenSource, the token is invalidated and the request is
+// retried once with a fresh one.
+func (c *Client) Do(ctx context.Context, req *Request, out interface{}) error {
+	var jsonData []byte
+	if req.Body != nil {
+		var err error
+		jsonData, err = json.Marshal(req.Body)
+		if err != nil {
+			return fmt.Errorf("marshaling request: %w", err)
+		}
+	}
+
+	attempts := c.retry.attempts(ctx, req)
+	for attempt := 1; ; attempt++ {
+		err := c.sendAuthenticated(ctx, req, jsonData, out)
+		if err == nil || attempt >= attempts || !c.retry.retryable(ctx, err) {
+			return err
+		}
+		if err := sleep(ctx, c.retry.Backoff(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// sendAuthenticated sends req, renewing a rejected token once
+func (c *Client) sendAuthenticated(ctx context.Context, req *Request, jsonData []byte, out interface{}) error {
+	err := c.send(ctx, req, jsonData, out)
+	if rts, ok := c.tokens.(RefreshableTokenSource); ok && !req.NoAuth && errors.Is(err, ErrTokenExpired) {
+		rts.Invalidate()
+		err = c.send(ctx, req, jsonData, out)
+	}
+	return err
+}
+
+// send performs a single HTTP round trip for req
+func (c *Client) send(ctx context.Context, req *Request, jsonData []byte, out interface{}) error {
+	if err := c.limits.wait(ctx, req.Path); err != nil {
+		return err
+	}
+
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if jsonData != nil {
+		body = bytes.NewReader(jsonData)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, c.baseURL+req.Path, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	for k, v := range req.Header {
+		httpReq.Header[k] = v
+	}
+	if jsonData != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if !req.NoAuth && c.tokens != nil {
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("getting token: %w", err)
+		}
+		httpReq.Header.Set("X-Token", token)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return &transportError{err}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &transportError{err}
+	}
+
+	apiErr := &APIError{
+		HTTPStatus: resp.StatusCode,
+		Method:     req.Method,
+		Endpoint:   req.Path,
+		RequestID:  requestID(resp.Header),
+		Message:    resp.Header.Get("X-Ca-Error-Message"),
+	}
+
+	var env Envelope
+	if err := json.Unmarshal(respBody, &env); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return apiErr
+		}
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || env.Status != 200 {
+		apiErr.Status = env.Status
+		if env.Message != "" {
+			apiErr.Message = env.Message
+		}
+		return apiErr
+	}
+
+	if out != nil && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return fmt.Errorf("parsing response data: %w", err)
+		}
+	}
+	return nil
+}
+
+// requestID extracts the request ID assigned by the API gateway
+func requestID(h http.Header) string {
+	if id := h.Get("X-Ca-Request-Id"); id != "" {
+		return id
+	}
+	return h.Get("X-Request-Id")
+}
@@ -0,0 +1,191 @@
+package axapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_Do(t *testing.T) {
+	var gotToken, gotContentType string
+	var gotBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("X-Token")
+		gotContentType = r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Write([]byte(`{"status":200,"data":{"taskId":"t1"}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(&Config{URLPrefix: srv.URL}, WithTokenSource(StaticToken("tok")))
+
+	var data struct {
+		TaskId string `json:"taskId"`
+	}
+	err := client.Do(context.Background(), &Request{Method: http.MethodPost, Path: "/task/v1.1", Body: map[string]interface{}{"name": "n"}}, &data)
+	if err != nil {
+		t.Fatalf("Client.Do() error = %v", err)
+	}
+	if data.TaskId != "t1" {
+		t.Errorf("TaskId = %q, want %q", data.TaskId, "t1")
+	}
+	if gotToken != "tok" {
+		t.Errorf("X-Token = %q, want %q", gotToken, "tok")
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", gotContentType)
+	}
+	if gotBody["name"] != "n" {
+		t.Errorf("body = %v, want name=n", gotBody)
+	}
+}
+
+func TestClient_DoNoAuth(t *testing.T) {
+	var gotToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("X-Token")
+		w.Write([]byte(`{"status":200}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(&Config{URLPrefix: srv.URL}, WithTokenSource(StaticToken("tok")))
+	if err := client.Do(context.Background(), &Request{Method: http.MethodPost, Path: "/auth/v1.1/token", NoAuth: true}, nil); err != nil {
+		t.Fatalf("Client.Do() error = %v", err)
+	}
+	if gotToken != "" {
+		t.Errorf("X-Token = %q, want empty", gotToken)
+	}
+}
+
+func TestClient_DoErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   int
+		body   string
+		hasErr bool
+	}{
+		{name: "ok", code: http.StatusOK, body: `{"status":200,"data":{}}`},
+		{name: "http status", code: http.StatusInternalServerError, body: ``, hasErr: true},
+		{name: "api status", code: http.StatusOK, body: `{"status":404,"message":"not found"}`, hasErr: true},
+		{name: "bad json", code: http.StatusOK, body: `{`, hasErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.code)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			client := NewClient(&Config{URLPrefix: srv.URL}, WithRetryPolicy(NoRetry))
+			err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/x"}, nil)
+			if (err != nil) != tt.hasErr {
+				t.Errorf("Client.Do() error = %v, want error %v", err, tt.hasErr)
+			}
+		})
+	}
+}
+
+func TestClient_DoContext(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`{"status":200}`))
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	t.Run("default timeout", func(t *testing.T) {
+		client := NewClient(&Config{URLPrefix: srv.URL}, WithTimeout(50*time.Millisecond), WithRetryPolicy(NoRetry))
+		err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/x"}, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Client.Do() error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("context deadline overrides default", func(t *testing.T) {
+		client := NewClient(&Config{URLPrefix: srv.URL}, WithTimeout(time.Millisecond))
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		client.Do(ctx, &Request{Method: http.MethodGet, Path: "/x"}, nil)
+		if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+			t.Errorf("Client.Do() returned after %v, want the context deadline to apply", elapsed)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		client := NewClient(&Config{URLPrefix: srv.URL})
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/x"}, nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Client.Do() error = %v, want context.Canceled", err)
+		}
+	})
+}
+
+type countingTokenSource struct {
+	tokens      []string
+	invalidated int
+}
+
+func (s *countingTokenSource) Token(ctx context.Context) (string, error) {
+	return s.tokens[s.invalidated], nil
+}
+
+func (s *countingTokenSource) Invalidate() {
+	s.invalidated++
+}
+
+func TestClient_DoRefreshesExpiredToken(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("X-Token"))
+		if r.Header.Get("X-Token") == "old" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status":200}`))
+	}))
+	defer srv.Close()
+
+	ts := &countingTokenSource{tokens: []string{"old", "new", "newer"}}
+	client := NewClient(&Config{URLPrefix: srv.URL}, WithTokenSource(ts))
+	if err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/x"}, nil); err != nil {
+		t.Fatalf("Client.Do() error = %v", err)
+	}
+	if ts.invalidated != 1 {
+		t.Errorf("Invalidate called %d times, want 1", ts.invalidated)
+	}
+	if want := []string{"old", "new"}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("tokens sent = %v, want %v", seen, want)
+	}
+}
+
+func TestClient_DoRetriesTokenOnlyOnce(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status":401,"message":"token invalid"}`))
+	}))
+	defer srv.Close()
+
+	ts := &countingTokenSource{tokens: []string{"a", "b", "c"}}
+	client := NewClient(&Config{URLPrefix: srv.URL}, WithTokenSource(ts))
+	err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/x"}, nil)
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Client.Do() error = %v, want ErrTokenExpired", err)
+	}
+	if calls != 2 {
+		t.Errorf("server called %d times, want 2", calls)
+	}
+}
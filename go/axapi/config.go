@@ -0,0 +1,239 @@
+// Package axapi is the root of the Autoxing cloud API SDK.
+//
+// The subpackages wrap the individual API groups:
+//
+//	auth    - token acquisition (/auth)
+//	robot   - robot list and state (/robot)
+//	task    - task building and execution (/task)
+//	mapinfo - POI lookup (/map)
+package axapi
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Region names an Autoxing deployment
+type Region string
+
+// Known regions
+const (
+	// RegionChina is for users in China
+	RegionChina Region = "cn"
+	// RegionGlobal is for overseas users
+	RegionGlobal Region = "global"
+)
+
+var regionURLPrefixes = map[Region]string{
+	RegionChina:  "https://api.autoxing.com",
+	RegionGlobal: "https://apiglobal.autoxing.com",
+}
+
+// URLPrefix returns the API URL prefix of the region
+func (r Region) URLPrefix() (string, bool) {
+	prefix, ok := regionURLPrefixes[r]
+	return prefix, ok
+}
+
+// authorizationScheme prefixes the app code in the Authorization header
+const authorizationScheme = "APPCODE "
+
+// Config struct to hold configuration.
+// The field names double as the keys of JSON and YAML config files, which
+// matches python3/config.py.
+type Config struct {
+	// Region selects URLPrefix when URLPrefix itself is empty
+	Region        Region `json:"Region,omitempty" yaml:"Region,omitempty"`
+	URLPrefix     string `json:"URLPrefix,omitempty" yaml:"URLPrefix,omitempty"`
+	APPID         string `json:"APPID,omitempty" yaml:"APPID,omitempty"`
+	APPSecret     string `json:"APPSecret,omitempty" yaml:"APPSecret,omitempty"`
+	Authorization string `json:"Authorization,omitempty" yaml:"Authorization,omitempty"`
+	RobotID       string `json:"RobotID,omitempty" yaml:"RobotID,omitempty"`
+}
+
+// String implements fmt.Stringer with the secrets redacted
+func (c Config) String() string {
+	return fmt.Sprintf("{Region:%s URLPrefix:%s APPID:%s APPSecret:%s Authorization:%s RobotID:%s}",
+		c.Region, c.URLPrefix, c.APPID, redact(c.APPSecret), redact(c.Authorization), c.RobotID)
+}
+
+// GoString implements fmt.GoStringer with the secrets redacted
+func (c Config) GoString() string {
+	return "axapi.Config" + c.String()
+}
+
+func redact(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "REDACTED"
+}
+
+// Validate resolves the region and checks that the required fields are set
+func (c *Config) Validate() error {
+	if c.URLPrefix == "" && c.Region != "" {
+		prefix, ok := c.Region.URLPrefix()
+		if !ok {
+			return fmt.Errorf("axapi: unknown region %q", c.Region)
+		}
+		c.URLPrefix = prefix
+	}
+	c.URLPrefix = strings.TrimRight(c.URLPrefix, "/")
+	if c.Authorization != "" && !strings.HasPrefix(c.Authorization, authorizationScheme) {
+		c.Authorization = authorizationScheme + c.Authorization
+	}
+
+	var errs []error
+	if c.URLPrefix == "" {
+		errs = append(errs, errors.New("axapi: URLPrefix or Region is required"))
+	}
+	if c.APPID == "" {
+		errs = append(errs, errors.New("axapi: APPID is required"))
+	}
+	if c.APPSecret == "" {
+		errs = append(errs, errors.New("axapi: APPSecret is required"))
+	}
+	if c.Authorization == "" {
+		errs = append(errs, errors.New("axapi: Authorization is required"))
+	}
+	return errors.Join(errs...)
+}
+
+// Environment variables read by LoadConfig
+const (
+	EnvRegion        = "REGION"
+	EnvURLPrefix     = "URL_PREFIX"
+	EnvAPPID         = "APP_ID"
+	EnvAPPSecret     = "APP_SECRET"
+	EnvAuthorization = "Authorization"
+	EnvRobotID       = "RobotID"
+)
+
+// LoadConfig builds a Config from, in increasing order of precedence,
+// the file at path (skipped when path is empty) and the environment.
+// The Authorization may be given with or without the "APPCODE " prefix.
+func LoadConfig(path string) (*Config, error) {
+	return loadConfig(path, os.LookupEnv, nil)
+}
+
+// ConfigFlags holds configuration given on the command line
+type ConfigFlags struct {
+	File   string
+	values Config
+	set    map[string]bool
+	fs     *flag.FlagSet
+}
+
+// RegisterConfigFlags defines -config, -region, -url-prefix, -app-id,
+// -app-secret, -authorization and -robot-id on fs
+func RegisterConfigFlags(fs *flag.FlagSet) *ConfigFlags {
+	f := &ConfigFlags{fs: fs}
+	fs.StringVar(&f.File, "config", "", "JSON or YAML config file")
+	fs.StringVar((*string)(&f.values.Region), "region", "", "API region: cn or global")
+	fs.StringVar(&f.values.URLPrefix, "url-prefix", "", "API URL prefix, overrides -region")
+	fs.StringVar(&f.values.APPID, "app-id", "", "application ID")
+	fs.StringVar(&f.values.APPSecret, "app-secret", "", "application secret")
+	fs.StringVar(&f.values.Authorization, "authorization", "", "APPCODE authorization")
+	fs.StringVar(&f.values.RobotID, "robot-id", "", "robot ID")
+	return f
+}
+
+// Load builds a Config from, in increasing order of precedence, the -config
+// file, the environment and the flags explicitly set on the command line.
+// It must be called after the FlagSet has been parsed.
+func (f *ConfigFlags) Load() (*Config, error) {
+	set := map[string]bool{}
+	f.fs.Visit(func(fl *flag.Flag) {
+		set[fl.Name] = true
+	})
+	return loadConfig(f.File, os.LookupEnv, func(c *Config) {
+		if set["region"] {
+			c.Region = f.values.Region
+			if !set["url-prefix"] {
+				c.URLPrefix = ""
+			}
+		}
+		if set["url-prefix"] {
+			c.URLPrefix = f.values.URLPrefix
+		}
+		if set["app-id"] {
+			c.APPID = f.values.APPID
+		}
+		if set["app-secret"] {
+			c.APPSecret = f.values.APPSecret
+		}
+		if set["authorization"] {
+			c.Authorization = f.values.Authorization
+		}
+		if set["robot-id"] {
+			c.RobotID = f.values.RobotID
+		}
+	})
+}
+
+// loadConfig layers file, environment and overrides, then validates
+func loadConfig(path string, lookupEnv func(string) (string, bool), override func(*Config)) (*Config, error) {
+	c := &Config{}
+	if path != "" {
+		if err := c.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	c.applyEnv(lookupEnv)
+	if override != nil {
+		override(c)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// readFile decodes path as YAML or JSON depending on its extension
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("axapi: reading config: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	default:
+		err = json.Unmarshal(data, c)
+	}
+	if err != nil {
+		return fmt.Errorf("axapi: parsing config %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides fields whose environment variable is set.
+// Setting REGION without URL_PREFIX replaces a URLPrefix from the file.
+func (c *Config) applyEnv(lookupEnv func(string) (string, bool)) {
+	if v, ok := lookupEnv(EnvRegion); ok && v != "" {
+		c.Region = Region(v)
+		c.URLPrefix = ""
+	}
+	fields := []struct {
+		name string
+		dst  *string
+	}{
+		{EnvURLPrefix, &c.URLPrefix},
+		{EnvAPPID, &c.APPID},
+		{EnvAPPSecret, &c.APPSecret},
+		{EnvAuthorization, &c.Authorization},
+		{EnvRobotID, &c.RobotID},
+	}
+	for _, f := range fields {
+		if v, ok := lookupEnv(f.name); ok && v != "" {
+			*f.dst = v
+		}
+	}
+}
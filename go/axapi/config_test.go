@@ -0,0 +1,132 @@
+package axapi
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func envMap(m map[string]string) func(string) (string, bool) {
+	return func(k string) (string, bool) {
+		v, ok := m[k]
+		return v, ok
+	}
+}
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "config.yaml")
+	os.WriteFile(yamlPath, []byte("Region: cn\nAPPID: file-id\nAPPSecret: file-secret\nAuthorization: file-code\nRobotID: file-robot\n"), 0o600)
+	jsonPath := filepath.Join(dir, "config.json")
+	os.WriteFile(jsonPath, []byte(`{"URLPrefix":"https://example.com/","APPID":"file-id","APPSecret":"s","Authorization":"APPCODE c"}`), 0o600)
+
+	tests := []struct {
+		name     string
+		path     string
+		env      map[string]string
+		override func(*Config)
+		want     Config
+	}{
+		{
+			name: "yaml file with region",
+			path: yamlPath,
+			want: Config{Region: RegionChina, URLPrefix: "https://api.autoxing.com", APPID: "file-id",
+				APPSecret: "file-secret", Authorization: "APPCODE file-code", RobotID: "file-robot"},
+		},
+		{
+			name: "json file",
+			path: jsonPath,
+			want: Config{URLPrefix: "https://example.com", APPID: "file-id", APPSecret: "s", Authorization: "APPCODE c"},
+		},
+		{
+			name: "env overrides file",
+			path: yamlPath,
+			env:  map[string]string{"REGION": "global", "APP_ID": "env-id", "Authorization": "env-code"},
+			want: Config{Region: RegionGlobal, URLPrefix: "https://apiglobal.autoxing.com", APPID: "env-id",
+				APPSecret: "file-secret", Authorization: "APPCODE env-code", RobotID: "file-robot"},
+		},
+		{
+			name:     "flags override env",
+			path:     yamlPath,
+			env:      map[string]string{"APP_ID": "env-id"},
+			override: func(c *Config) { c.APPID = "flag-id" },
+			want: Config{Region: RegionChina, URLPrefix: "https://api.autoxing.com", APPID: "flag-id",
+				APPSecret: "file-secret", Authorization: "APPCODE file-code", RobotID: "file-robot"},
+		},
+		{
+			name: "env only",
+			env: map[string]string{"URL_PREFIX": "https://x", "APP_ID": "i", "APP_SECRET": "s",
+				"Authorization": "c", "RobotID": "r"},
+			want: Config{URLPrefix: "https://x", APPID: "i", APPSecret: "s", Authorization: "APPCODE c", RobotID: "r"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadConfig(tt.path, envMap(tt.env), tt.override)
+			if err != nil {
+				t.Fatalf("loadConfig() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("loadConfig() = %#v, want %#v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigFlags_Load(t *testing.T) {
+	t.Setenv("URL_PREFIX", "https://env")
+	t.Setenv("APP_ID", "env-id")
+	t.Setenv("APP_SECRET", "env-secret")
+	t.Setenv("Authorization", "env-code")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := RegisterConfigFlags(fs)
+	if err := fs.Parse([]string{"-region", "global", "-app-id", "flag-id"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := flags.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := Config{Region: RegionGlobal, URLPrefix: "https://apiglobal.autoxing.com", APPID: "flag-id",
+		APPSecret: "env-secret", Authorization: "APPCODE env-code"}
+	if *got != want {
+		t.Errorf("Load() = %#v, want %#v", *got, want)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	err := (&Config{}).Validate()
+	for _, field := range []string{"URLPrefix", "APPID", "APPSecret", "Authorization"} {
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error = %v, want it to mention %s", err, field)
+		}
+	}
+
+	err = (&Config{Region: "mars", APPID: "i", APPSecret: "s", Authorization: "c"}).Validate()
+	if err == nil || !strings.Contains(err.Error(), "unknown region") {
+		t.Errorf("Validate() error = %v, want unknown region", err)
+	}
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.json"), envMap(nil), nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("loadConfig() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestConfig_Redaction(t *testing.T) {
+	c := &Config{URLPrefix: "https://x", APPID: "id", APPSecret: "top-secret", Authorization: "APPCODE code-secret"}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		out := fmt.Sprintf(format, c)
+		if strings.Contains(out, "top-secret") || strings.Contains(out, "code-secret") {
+			t.Errorf("Sprintf(%q) = %s, leaks a secret", format, out)
+		}
+		if !strings.Contains(out, "id") {
+			t.Errorf("Sprintf(%q) = %s, want APPID shown", format, out)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package axapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Sentinel errors that an *APIError can be matched against with errors.Is
+var (
+	ErrTokenExpired = errors.New("axapi: token expired or invalid")
+	ErrRobotOffline = errors.New("axapi: robot offline")
+	ErrNotFound     = errors.New("axapi: not found")
+	ErrRateLimited  = errors.New("axapi: rate limited")
+)
+
+// APIError describes a failed API call.
+// HTTPStatus is always set; Status and Message are only set when the
+// server returned a parseable envelope.
+type APIError struct {
+	HTTPStatus int
+	Status     int
+	Message    string
+	Method     string
+	Endpoint   string
+	RequestID  string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "axapi: %s %s: ", e.Method, e.Endpoint)
+	if e.HTTPStatus != http.StatusOK {
+		fmt.Fprintf(&b, "http status %d", e.HTTPStatus)
+	} else {
+		fmt.Fprintf(&b, "status %d", e.Status)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request id %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrTokenExpired:
+		return e.code() == http.StatusUnauthorized || slices.Contains(tokenMessages, normalize(e.Message))
+	case ErrNotFound:
+		return e.code() == http.StatusNotFound
+	case ErrRateLimited:
+		return e.code() == http.StatusTooManyRequests
+	case ErrRobotOffline:
+		return e.mentions("offline")
+	}
+	return false
+}
+
+// tokenMessages are the messages, normalized, with which the server rejects
+// a token without a 401 status
+var tokenMessages = []string{
+	"token expired",
+	"token is expired",
+	"token invalid",
+	"token is invalid",
+	"invalid token",
+}
+
+// normalize lower-cases msg and trims spaces and trailing punctuation
+func normalize(msg string) string {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(msg)), ".!")
+}
+
+// code returns the envelope status, falling back to the HTTP status
+func (e *APIError) code() int {
+	if e.HTTPStatus != http.StatusOK {
+		return e.HTTPStatus
+	}
+	return e.Status
+}
+
+// mentions reports whether the server message contains word
+func (e *APIError) mentions(word string) bool {
+	return strings.Contains(strings.ToLower(e.Message), word)
+}
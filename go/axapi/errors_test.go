@@ -0,0 +1,65 @@
+package axapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *APIError
+		target error
+		want   bool
+	}{
+		{name: "http 401", err: &APIError{HTTPStatus: 401}, target: ErrTokenExpired, want: true},
+		{name: "status 401", err: &APIError{HTTPStatus: 200, Status: 401}, target: ErrTokenExpired, want: true},
+		{name: "token message", err: &APIError{HTTPStatus: 200, Status: 500, Message: "Token expired"}, target: ErrTokenExpired, want: true},
+		{name: "invalid token message", err: &APIError{HTTPStatus: 200, Status: 500, Message: "Invalid token."}, target: ErrTokenExpired, want: true},
+		{name: "unrelated token message", err: &APIError{HTTPStatus: 200, Status: 400, Message: "tokenId is required for this task"}, target: ErrTokenExpired, want: false},
+		{name: "status 404", err: &APIError{HTTPStatus: 200, Status: 404}, target: ErrNotFound, want: true},
+		{name: "http 404", err: &APIError{HTTPStatus: 404}, target: ErrNotFound, want: true},
+		{name: "offline", err: &APIError{HTTPStatus: 200, Status: 500, Message: "robot is offline"}, target: ErrRobotOffline, want: true},
+		{name: "other", err: &APIError{HTTPStatus: 200, Status: 500, Message: "boom"}, target: ErrNotFound, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_DoAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ca-Request-Id", "req-1")
+		w.Write([]byte(`{"status":404,"message":"task not found"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(&Config{URLPrefix: srv.URL})
+	err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/task/v1.1/x"}, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Client.Do() error = %v, want *APIError", err)
+	}
+	want := APIError{
+		HTTPStatus: 200,
+		Status:     404,
+		Message:    "task not found",
+		Method:     http.MethodGet,
+		Endpoint:   "/task/v1.1/x",
+		RequestID:  "req-1",
+	}
+	if *apiErr != want {
+		t.Errorf("APIError = %+v, want %+v", *apiErr, want)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false", err)
+	}
+}
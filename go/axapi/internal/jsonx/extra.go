@@ -0,0 +1,109 @@
+// Package jsonx decodes JSON objects into structs while keeping the fields
+// the struct does not know about, so new server fields are not lost.
+package jsonx
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var knownFields sync.Map // reflect.Type -> map[string]bool
+
+// known returns the JSON keys of the exported fields of struct type t
+func known(t reflect.Type) map[string]bool {
+	if v, ok := knownFields.Load(t); ok {
+		return v.(map[string]bool)
+	}
+	keys := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			// the fields of an embedded struct are promoted
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k := range known(ft) {
+					keys[k] = true
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		keys[name] = true
+	}
+	knownFields.Store(t, keys)
+	return keys
+}
+
+// matches reports whether key is in keys, ignoring case the way
+// encoding/json does when it matches keys to fields
+func matches(keys map[string]bool, key string) bool {
+	if keys[key] {
+		return true
+	}
+	for k := range keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalWithExtra decodes data into v, a pointer to a struct without a
+// custom UnmarshalJSON, and stores the keys v has no field for in extra
+func UnmarshalWithExtra(data []byte, v interface{}, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	keys := known(reflect.TypeOf(v).Elem())
+	for k := range all {
+		if matches(keys, k) {
+			delete(all, k)
+		}
+	}
+	if len(all) == 0 {
+		all = nil
+	}
+	*extra = all
+	return nil
+}
+
+// MarshalWithExtra encodes v, a struct without a custom MarshalJSON, and
+// adds the keys of extra that v does not set itself
+func MarshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(all))
+	for k := range all {
+		set[k] = true
+	}
+	for k, raw := range extra {
+		if !matches(set, k) {
+			all[k] = raw
+		}
+	}
+	return json.Marshal(all)
+}
@@ -0,0 +1,66 @@
+// Package mapinfo looks up map data such as points of interest.
+package mapinfo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// POI represents a point of interest
+type POI struct {
+	ID         string                 `json:"id"`
+	AreaID     string                 `json:"areaId"`
+	BuildingID string                 `json:"buildingId"`
+	BusinessID string                 `json:"businessId"`
+	Coordinate []float64              `json:"coordinate"`
+	Floor      int                    `json:"floor"`
+	FloorName  string                 `json:"floorName"`
+	Name       string                 `json:"name"`
+	Type       int                    `json:"type"`
+	Version    string                 `json:"version"`
+	Yaw        float64                `json:"yaw"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// MapInfoManager handles map-related operations
+type MapInfoManager struct {
+	client *axapi.Client
+}
+
+// NewMapInfoManager creates a new instance of MapInfoManager
+func NewMapInfoManager(client *axapi.Client) *MapInfoManager {
+	return &MapInfoManager{client: client}
+}
+
+// GetPoiList retrieves the POIs filtered by business, robot or area.
+// Empty arguments are left out of the query; for general development
+// filtering by robotId is recommended.
+func (mm *MapInfoManager) GetPoiList(ctx context.Context, businessId, robotId, areaId string) ([]POI, error) {
+	body := map[string]interface{}{}
+	if businessId != "" {
+		body["businessId"] = businessId
+	}
+	if robotId != "" {
+		body["robotId"] = robotId
+	}
+	if areaId != "" {
+		body["areaId"] = areaId
+	}
+
+	req := &axapi.Request{
+		Method:     http.MethodPost,
+		Path:       "/map/v1.1/poi/list",
+		Idempotent: true,
+		Body:       body,
+	}
+
+	var data struct {
+		List []POI `json:"list"`
+	}
+	if err := mm.client.Do(ctx, req, &data); err != nil {
+		return nil, err
+	}
+	return data.List, nil
+}
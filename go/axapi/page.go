@@ -0,0 +1,152 @@
+package axapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// DefaultPageSize is the page size used when PageRequest.PageSize is 0
+const DefaultPageSize = 50
+
+// PageRequest describes one page of a list endpoint, which takes pageSize
+// and pageNum in a POST body and returns {"list":[...],"total":n}
+type PageRequest[T any] struct {
+	Path string
+	// PageSize defaults to DefaultPageSize
+	PageSize int
+	// PageNum starts at 1, which is also the default
+	PageNum int
+	// Body holds the filters sent along with pageSize and pageNum
+	Body map[string]interface{}
+	// Keep, if set, filters the items of the page once it is fetched, for
+	// filters the server does not support. Such pages may hold fewer than
+	// PageSize items, or none while more pages follow.
+	Keep func(T) bool
+}
+
+// Page is one page of a list endpoint
+type Page[T any] struct {
+	List     []T
+	PageNum  int
+	PageSize int
+	// Total is the number of items matching the filters in the request
+	// body, or 0 if the server did not report it
+	Total int
+	// HasMore reports whether another page follows
+	HasMore bool
+}
+
+// GetPage fetches the page described by r
+func GetPage[T any](ctx context.Context, c *Client, r PageRequest[T]) (*Page[T], error) {
+	if r.PageSize <= 0 {
+		r.PageSize = DefaultPageSize
+	}
+	if r.PageNum <= 0 {
+		r.PageNum = 1
+	}
+
+	body := map[string]interface{}{
+		"pageSize": r.PageSize,
+		"pageNum":  r.PageNum,
+	}
+	for k, v := range r.Body {
+		body[k] = v
+	}
+	req := &Request{
+		Method:     http.MethodPost,
+		Path:       r.Path,
+		Idempotent: true,
+		Body:       body,
+	}
+
+	var data struct {
+		List  []T `json:"list"`
+		Total int `json:"total"`
+	}
+	if err := c.Do(ctx, req, &data); err != nil {
+		return nil, err
+	}
+
+	page := &Page[T]{
+		List:     data.List,
+		PageNum:  r.PageNum,
+		PageSize: r.PageSize,
+		Total:    data.Total,
+		HasMore:  len(data.List) == r.PageSize,
+	}
+	if data.Total > 0 {
+		page.HasMore = r.PageNum*r.PageSize < data.Total
+	}
+	if r.Keep != nil {
+		kept := page.List[:0]
+		for _, item := range page.List {
+			if r.Keep(item) {
+				kept = append(kept, item)
+			}
+		}
+		page.List = kept
+	}
+	return page, nil
+}
+
+// Pager iterates over a list page by page. Typical use:
+//
+//	for p.Next(ctx) {
+//		item := p.Item()
+//	}
+//	if err := p.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	fetch   func(ctx context.Context, pageNum int) (*Page[T], error)
+	pageNum int
+	page    []T
+	cur     T
+	more    bool
+	err     error
+}
+
+// NewPager creates a Pager that gets pages from fetch, starting at page 1
+func NewPager[T any](fetch func(ctx context.Context, pageNum int) (*Page[T], error)) *Pager[T] {
+	return &Pager[T]{fetch: fetch, more: true}
+}
+
+// Next advances to the next item, fetching the next page when the current
+// one is exhausted. It returns false at the end or on error.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	for len(p.page) == 0 {
+		if !p.more || p.err != nil {
+			return false
+		}
+		p.pageNum++
+		page, err := p.fetch(ctx, p.pageNum)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.page = page.List
+		p.more = page.HasMore
+	}
+	p.cur = p.page[0]
+	p.page = p.page[1:]
+	return true
+}
+
+// Item returns the item Next advanced to
+func (p *Pager[T]) Item() T {
+	return p.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All iterates over the remaining items and returns them
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for p.Next(ctx) {
+		items = append(items, p.Item())
+	}
+	return items, p.Err()
+}
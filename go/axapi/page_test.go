@@ -0,0 +1,120 @@
+package axapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type pageItem struct {
+	ID  int  `json:"id"`
+	Odd bool `json:"odd"`
+}
+
+// listServer serves pages over n items. It reports the total only if
+// withTotal is set.
+func listServer(n int, withTotal bool, bodies *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		*bodies = append(*bodies, body)
+		size := int(body["pageSize"].(float64))
+		num := int(body["pageNum"].(float64))
+
+		var list []string
+		for i := (num - 1) * size; i < num*size && i < n; i++ {
+			list = append(list, fmt.Sprintf(`{"id":%d,"odd":%v}`, i, i%2 == 1))
+		}
+		total := ""
+		if withTotal {
+			total = fmt.Sprintf(`,"total":%d`, n)
+		}
+		fmt.Fprintf(w, `{"status":200,"data":{"list":[%s]%s}}`, strings.Join(list, ","), total)
+	}))
+}
+
+func TestGetPage(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := listServer(25, true, &bodies)
+	defer srv.Close()
+
+	client := NewClient(&Config{URLPrefix: srv.URL})
+	page, err := GetPage(context.Background(), client, PageRequest[pageItem]{
+		Path:     "/x/v1.1/list",
+		PageSize: 10,
+		PageNum:  2,
+		Body:     map[string]interface{}{"keyWord": "kw"},
+		Keep:     func(it pageItem) bool { return it.Odd },
+	})
+	if err != nil {
+		t.Fatalf("GetPage() error = %v", err)
+	}
+	var ids []int
+	for _, it := range page.List {
+		ids = append(ids, it.ID)
+	}
+	if want := []int{11, 13, 15, 17, 19}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("GetPage() = %v, want %v", ids, want)
+	}
+	if page.PageNum != 2 || page.PageSize != 10 || page.Total != 25 || !page.HasMore {
+		t.Errorf("GetPage() = %+v, want page 2 of size 10, total 25, more", page)
+	}
+	want := map[string]interface{}{"pageSize": 10.0, "pageNum": 2.0, "keyWord": "kw"}
+	if !reflect.DeepEqual(bodies[0], want) {
+		t.Errorf("request body = %v, want %v", bodies[0], want)
+	}
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         int
+		withTotal bool
+		pageSize  int
+		keep      func(pageItem) bool
+		want      int
+		wantCalls int
+	}{
+		{name: "default page size", n: 12, withTotal: true, want: 12, wantCalls: 1},
+		{name: "several pages with total", n: 25, withTotal: true, pageSize: 10, want: 25, wantCalls: 3},
+		{name: "several pages without total", n: 20, pageSize: 10, want: 20, wantCalls: 3},
+		{name: "filtered", n: 25, withTotal: true, pageSize: 10, keep: func(it pageItem) bool { return it.Odd }, want: 12, wantCalls: 3},
+		{name: "filtered page empty", n: 25, withTotal: true, pageSize: 10, keep: func(it pageItem) bool { return it.ID >= 20 }, want: 5, wantCalls: 3},
+		{name: "empty", n: 0, withTotal: true, want: 0, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []map[string]interface{}
+			srv := listServer(tt.n, tt.withTotal, &bodies)
+			defer srv.Close()
+
+			client := NewClient(&Config{URLPrefix: srv.URL})
+			p := NewPager(func(ctx context.Context, pageNum int) (*Page[pageItem], error) {
+				return GetPage(ctx, client, PageRequest[pageItem]{Path: "/x", PageSize: tt.pageSize, PageNum: pageNum, Keep: tt.keep})
+			})
+			items, err := p.All(context.Background())
+			if err != nil {
+				t.Fatalf("All() error = %v", err)
+			}
+			if len(items) != tt.want {
+				t.Errorf("All() returned %d items, want %d", len(items), tt.want)
+			}
+			if len(bodies) != tt.wantCalls {
+				t.Errorf("All() made %d requests, want %d", len(bodies), tt.wantCalls)
+			}
+		})
+	}
+
+	// an error stops the iteration
+	p := NewPager(func(ctx context.Context, pageNum int) (*Page[pageItem], error) {
+		return nil, ErrNotFound
+	})
+	if p.Next(context.Background()) || p.Err() != ErrNotFound {
+		t.Errorf("Next() after error, Err() = %v", p.Err())
+	}
+}
@@ -0,0 +1,219 @@
+package axapi
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointGroup is the API group a request belongs to, taken from the first
+// segment of its path
+type EndpointGroup string
+
+// Endpoint groups used by the SDK
+const (
+	GroupAuth  EndpointGroup = "auth"
+	GroupRobot EndpointGroup = "robot"
+	GroupTask  EndpointGroup = "task"
+	GroupMap   EndpointGroup = "map"
+)
+
+// groupOf returns the endpoint group of a request path such as
+// "/robot/v1.1/list"
+func groupOf(path string) EndpointGroup {
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[:i]
+	}
+	return EndpointGroup(path)
+}
+
+// RateLimit is a token bucket refilled at Rate requests per second that
+// allows bursts of up to Burst requests
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitMode decides what happens to a call that exceeds the rate limit
+type RateLimitMode int
+
+const (
+	// RateLimitQueue delays the call until the limiter allows it, or fails
+	// with ErrRateLimited if that would overrun the context deadline
+	RateLimitQueue RateLimitMode = iota
+	// RateLimitReject fails the call with ErrRateLimited immediately
+	RateLimitReject
+)
+
+// RateLimitObserver is told how long each call waited for the limiter
+type RateLimitObserver func(ctx context.Context, group EndpointGroup, waited time.Duration)
+
+// WithRateLimit limits all requests of the client together
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *Client) {
+		c.limits.global = NewRateLimiter(limit)
+	}
+}
+
+// WithEndpointRateLimit limits the requests of one endpoint group, in
+// addition to any client-wide limit
+func WithEndpointRateLimit(group EndpointGroup, limit RateLimit) Option {
+	return func(c *Client) {
+		if c.limits.groups == nil {
+			c.limits.groups = map[EndpointGroup]*RateLimiter{}
+		}
+		c.limits.groups[group] = NewRateLimiter(limit)
+	}
+}
+
+// WithRateLimitMode sets whether calls over the limit are queued (the
+// default) or rejected
+func WithRateLimitMode(mode RateLimitMode) Option {
+	return func(c *Client) {
+		c.limits.mode = mode
+	}
+}
+
+// WithRateLimitObserver registers a callback reporting the time every call
+// spent waiting for the rate limiter
+func WithRateLimitObserver(observer RateLimitObserver) Option {
+	return func(c *Client) {
+		c.limits.observer = observer
+	}
+}
+
+// rateLimits holds the limiters of a client
+type rateLimits struct {
+	global   *RateLimiter
+	groups   map[EndpointGroup]*RateLimiter
+	mode     RateLimitMode
+	observer RateLimitObserver
+}
+
+// wait blocks until the request to path is allowed by every applicable
+// limiter and reports the total time spent waiting to the observer.
+// If a limiter refuses the request, the tokens already taken from the
+// others are given back.
+func (rl *rateLimits) wait(ctx context.Context, path string) error {
+	group := groupOf(path)
+	var waited time.Duration
+	var taken []*RateLimiter
+	fail := func(err error) error {
+		for _, l := range taken {
+			l.refund()
+		}
+		return err
+	}
+	for _, l := range []*RateLimiter{rl.global, rl.groups[group]} {
+		if l == nil {
+			continue
+		}
+		if rl.mode == RateLimitReject {
+			if !l.Allow() {
+				return fail(ErrRateLimited)
+			}
+			taken = append(taken, l)
+			continue
+		}
+		d, err := l.Wait(ctx)
+		waited += d
+		if err != nil {
+			return fail(err)
+		}
+		taken = append(taken, l)
+	}
+	if rl.observer != nil && (rl.global != nil || rl.groups[group] != nil) {
+		rl.observer(ctx, group, waited)
+	}
+	return nil
+}
+
+// RateLimiter is a token bucket rate limiter, safe for concurrent use
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter that starts with a full bucket.
+// A Burst below 1 is treated as 1.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// advance refills the bucket up to now. l.mu must be held.
+func (l *RateLimiter) advance(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// Allow takes a token if one is available without waiting
+func (l *RateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.advance(time.Now())
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// refund gives back a token taken by Allow or Wait
+func (l *RateLimiter) refund() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.advance(time.Now())
+	l.tokens = min(l.tokens+1, l.burst)
+}
+
+// Wait takes a token, waiting for one to become available, and returns how
+// long it waited. It fails with ErrRateLimited without waiting if the token
+// would only become available after the context deadline.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	now := time.Now()
+	l.advance(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return 0, nil
+	}
+	if l.rate <= 0 {
+		l.mu.Unlock()
+		return 0, ErrRateLimited
+	}
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		l.mu.Unlock()
+		return 0, ErrRateLimited
+	}
+	// Taking the token now, into debt, keeps waiting callers in order
+	l.tokens--
+	l.mu.Unlock()
+
+	start := time.Now()
+	if err := sleep(ctx, wait); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return time.Since(start), err
+	}
+	return time.Since(start), nil
+}
@@ -0,0 +1,142 @@
+package axapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupOf(t *testing.T) {
+	tests := map[string]EndpointGroup{
+		"/robot/v1.1/list":      GroupRobot,
+		"/robot/v1.1/abc/state": GroupRobot,
+		"/task/v1.1":            GroupTask,
+		"/auth/v1.1/token":      GroupAuth,
+		"/map/v1.1/poi/list":    GroupMap,
+		"business/v1.1/list":    "business",
+		"":                      "",
+	}
+	for path, want := range tests {
+		if got := groupOf(path); got != want {
+			t.Errorf("groupOf(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 50, Burst: 2})
+	if !l.Allow() || !l.Allow() {
+		t.Fatal("Allow() = false within burst")
+	}
+	if l.Allow() {
+		t.Fatal("Allow() = true after burst exhausted")
+	}
+
+	waited, err := l.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if waited < 10*time.Millisecond {
+		t.Errorf("Wait() waited %v, want about 20ms", waited)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Wait() past deadline error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":200}`))
+	}))
+	defer srv.Close()
+
+	var mu sync.Mutex
+	waits := map[EndpointGroup]time.Duration{}
+	client := NewClient(&Config{URLPrefix: srv.URL},
+		WithEndpointRateLimit(GroupRobot, RateLimit{Rate: 20, Burst: 1}),
+		WithRateLimitObserver(func(ctx context.Context, group EndpointGroup, waited time.Duration) {
+			mu.Lock()
+			waits[group] += waited
+			mu.Unlock()
+		}))
+
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/robot/v1.1/r/state"}, nil); err != nil {
+			t.Fatalf("Client.Do() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("3 robot calls took %v, want at least ~100ms at 20/s", elapsed)
+	}
+	if waits[GroupRobot] < 80*time.Millisecond {
+		t.Errorf("observed robot wait = %v, want at least ~100ms", waits[GroupRobot])
+	}
+
+	// other groups are not limited
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		client.Do(ctx, &Request{Method: http.MethodGet, Path: "/task/v1.1/t"}, nil)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("3 task calls took %v, want no rate limiting", elapsed)
+	}
+}
+
+func TestClient_RateLimitReject(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":200}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(&Config{URLPrefix: srv.URL},
+		WithRateLimit(RateLimit{Rate: 1, Burst: 1}),
+		WithRateLimitMode(RateLimitReject))
+
+	ctx := context.Background()
+	if err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/robot/v1.1/list"}, nil); err != nil {
+		t.Fatalf("first Client.Do() error = %v", err)
+	}
+	if err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/task/v1.1/t"}, nil); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("second Client.Do() error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestClient_RateLimitGlobalAndGroup(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":200}`))
+	}))
+	defer srv.Close()
+
+	for _, mode := range []RateLimitMode{RateLimitReject, RateLimitQueue} {
+		client := NewClient(&Config{URLPrefix: srv.URL},
+			WithRateLimit(RateLimit{Rate: 0.01, Burst: 2}),
+			WithEndpointRateLimit(GroupRobot, RateLimit{Rate: 0.01, Burst: 1}),
+			WithRateLimitMode(mode))
+
+		// in queue mode the deadline makes the robot limiter refuse
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		robot := &Request{Method: http.MethodGet, Path: "/robot/v1.1/r/state"}
+		if err := client.Do(ctx, robot, nil); err != nil {
+			t.Fatalf("mode %d: first robot call error = %v", mode, err)
+		}
+		for i := 0; i < 2; i++ {
+			if err := client.Do(ctx, robot, nil); !errors.Is(err, ErrRateLimited) {
+				t.Errorf("mode %d: robot call over the group limit error = %v, want ErrRateLimited", mode, err)
+			}
+		}
+		// the refused robot calls must not have used up the global bucket
+		if err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/task/v1.1/t"}, nil); err != nil {
+			t.Errorf("mode %d: task call error = %v, want the second global token", mode, err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,131 @@
+package axapi
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+// Only idempotent requests are retried unless the caller opts in with
+// RetryNonIdempotent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; 1 or less disables retries
+	MaxAttempts int
+	// InitialBackoff is the wait before the second attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after every attempt
+	Multiplier float64
+	// Jitter randomizes each wait by up to this fraction, e.g. 0.2 for ±20%
+	Jitter float64
+	// RetryableStatus lists the HTTP or envelope status codes worth retrying.
+	// Transport errors are always retryable.
+	RetryableStatus []int
+}
+
+// DefaultRetryPolicy is used by clients created without WithRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	RetryableStatus: []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// NoRetry disables retries
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// WithRetryPolicy sets the retry policy of the client
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+type retryNonIdempotentKey struct{}
+
+// RetryNonIdempotent returns a context that lets calls which are not
+// idempotent, such as creating or executing a task, be retried too.
+// Only use it when a duplicate request is harmless.
+func RetryNonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryNonIdempotentKey{}, true)
+}
+
+// attempts returns how many times req may be sent
+func (p RetryPolicy) attempts(ctx context.Context, req *Request) int {
+	if p.MaxAttempts <= 1 {
+		return 1
+	}
+	if req.Idempotent || req.Method == http.MethodGet {
+		return p.MaxAttempts
+	}
+	if allowed, _ := ctx.Value(retryNonIdempotentKey{}).(bool); allowed {
+		return p.MaxAttempts
+	}
+	return 1
+}
+
+// retryable reports whether err is worth another attempt
+func (p RetryPolicy) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return slices.Contains(p.RetryableStatus, apiErr.code())
+	}
+	var transportErr *transportError
+	return errors.As(err, &transportErr)
+}
+
+// Backoff returns the wait after the given failed attempt, starting at 1
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		d *= p.Multiplier
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(d)
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// transportError marks a failure to get any response from the server
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return "sending request: " + e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,95 @@
+package axapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var fastRetry = RetryPolicy{
+	MaxAttempts:     3,
+	InitialBackoff:  time.Millisecond,
+	MaxBackoff:      5 * time.Millisecond,
+	Multiplier:      2,
+	RetryableStatus: []int{http.StatusServiceUnavailable},
+}
+
+func TestClient_DoRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       Request
+		failures  int
+		code      int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "get recovers", req: Request{Method: http.MethodGet}, failures: 2, code: 503, wantCalls: 3},
+		{name: "get gives up", req: Request{Method: http.MethodGet}, failures: 5, code: 503, wantCalls: 3, wantErr: true},
+		{name: "idempotent post", req: Request{Method: http.MethodPost, Idempotent: true}, failures: 1, code: 503, wantCalls: 2},
+		{name: "post not retried", req: Request{Method: http.MethodPost}, failures: 1, code: 503, wantCalls: 1, wantErr: true},
+		{name: "post opt in", ctx: RetryNonIdempotent(context.Background()), req: Request{Method: http.MethodPost}, failures: 1, code: 503, wantCalls: 2},
+		{name: "status not retryable", req: Request{Method: http.MethodGet}, failures: 1, code: 400, wantCalls: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= tt.failures {
+					w.WriteHeader(tt.code)
+					return
+				}
+				w.Write([]byte(`{"status":200}`))
+			}))
+			defer srv.Close()
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			client := NewClient(&Config{URLPrefix: srv.URL}, WithRetryPolicy(fastRetry))
+			err := client.Do(ctx, &tt.req, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.Do() error = %v, want error %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("server called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClient_DoRetryTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	client := NewClient(&Config{URLPrefix: url}, WithRetryPolicy(fastRetry))
+	start := time.Now()
+	if err := client.Do(context.Background(), &Request{Method: http.MethodGet}, nil); err == nil {
+		t.Fatal("Client.Do() error = nil, want connection error")
+	}
+	if elapsed := time.Since(start); elapsed < 2*time.Millisecond {
+		t.Errorf("Client.Do() returned after %v, want it to back off between attempts", elapsed)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := p.Backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.Backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("backoff(1) with jitter = %v, want within 50ms..150ms", got)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package robot
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of parallel requests used by
+// GetRobotStates when concurrency is 0
+const DefaultBatchConcurrency = 8
+
+// StateResult is the outcome of fetching the state of one robot
+type StateResult struct {
+	RobotID string
+	State   RobotState
+	Err     error
+}
+
+// GetRobotStates fetches the states of many robots with at most
+// concurrency requests in flight. Results are in the order of robotIDs and
+// each carries its own error; the client's rate limits still apply.
+func (rm *RobotManager) GetRobotStates(ctx context.Context, robotIDs []string, concurrency int) []StateResult {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	if concurrency > len(robotIDs) {
+		concurrency = len(robotIDs)
+	}
+
+	results := make([]StateResult, len(robotIDs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				state, err := rm.GetRobotState(ctx, robotIDs[i])
+				results[i] = StateResult{RobotID: robotIDs[i], State: state, Err: err}
+			}
+		}()
+	}
+
+	for i := range robotIDs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
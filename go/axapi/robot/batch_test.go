@@ -0,0 +1,69 @@
+package robot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestRobotManager_GetRobotStates(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := strings.Split(r.URL.Path, "/")[3]
+		if id == "missing" {
+			w.Write([]byte(`{"status":404,"message":"robot not found"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"status":200,"data":{"robotId":%q,"isOnLine":true,"battery":50}}`, id)
+	}))
+	defer srv.Close()
+
+	manager := NewRobotManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	ids := []string{"r0", "r1", "missing", "r3", "r4", "r5", "r6", "r7", "r8", "r9"}
+	results := manager.GetRobotStates(context.Background(), ids, 3)
+
+	if len(results) != len(ids) {
+		t.Fatalf("GetRobotStates() returned %d results, want %d", len(results), len(ids))
+	}
+	for i, res := range results {
+		if res.RobotID != ids[i] {
+			t.Errorf("results[%d].RobotID = %q, want %q", i, res.RobotID, ids[i])
+		}
+		if ids[i] == "missing" {
+			if res.Err == nil {
+				t.Errorf("results[%d].Err = nil, want not found", i)
+			}
+			continue
+		}
+		if res.Err != nil || res.State.RobotID != ids[i] || res.State.Battery != 50 {
+			t.Errorf("results[%d] = %+v", i, res)
+		}
+	}
+	if m := atomic.LoadInt32(&maxInFlight); m > 3 || m < 2 {
+		t.Errorf("max concurrent requests = %d, want 2..3", m)
+	}
+}
+
+func TestRobotManager_GetRobotStatesEmpty(t *testing.T) {
+	manager := NewRobotManager(axapi.NewClient(&axapi.Config{}))
+	if results := manager.GetRobotStates(context.Background(), nil, 0); len(results) != 0 {
+		t.Errorf("GetRobotStates(nil) = %v, want empty", results)
+	}
+}
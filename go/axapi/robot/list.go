@@ -0,0 +1,95 @@
+package robot
+
+import (
+	"context"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// DefaultPageSize is the page size used when RobotListOptions.PageSize is 0
+const DefaultPageSize = axapi.DefaultPageSize
+
+// RobotListOptions pages and filters GetRobotList
+type RobotListOptions struct {
+	// PageSize defaults to DefaultPageSize
+	PageSize int
+	// PageNum starts at 1, which is also the default
+	PageNum int
+	// Keyword matches robot names and IDs
+	Keyword    string
+	BusinessID string
+	BuildingID string
+	// OnlineOnly drops offline robots. The server cannot filter on this,
+	// see axapi.PageRequest.Keep.
+	OnlineOnly bool
+}
+
+// RobotListPage is one page of GetRobotList
+type RobotListPage = axapi.Page[Robot]
+
+// GetRobotList retrieves one page of the robot list.
+// A nil opts fetches the first page with the default size.
+func (rm *RobotManager) GetRobotList(ctx context.Context, opts *RobotListOptions) (*RobotListPage, error) {
+	var o RobotListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	body := map[string]interface{}{}
+	if o.Keyword != "" {
+		body["keyWord"] = o.Keyword
+	}
+	if o.BusinessID != "" {
+		body["businessId"] = o.BusinessID
+	}
+	if o.BuildingID != "" {
+		body["buildingId"] = o.BuildingID
+	}
+	req := axapi.PageRequest[Robot]{
+		Path:     "/robot/v1.1/list",
+		PageSize: o.PageSize,
+		PageNum:  o.PageNum,
+		Body:     body,
+	}
+	if o.OnlineOnly {
+		req.Keep = func(r Robot) bool { return r.IsOnLine }
+	}
+	return axapi.GetPage(ctx, rm.client, req)
+}
+
+// ListAll walks every page and returns all robots matching opts.
+// opts.PageNum is ignored.
+func (rm *RobotManager) ListAll(ctx context.Context, opts *RobotListOptions) ([]Robot, error) {
+	return rm.Robots(opts).All(ctx)
+}
+
+// Robots returns an iterator over all robots matching opts, fetching pages
+// as needed. opts.PageNum is ignored.
+//
+//	it := manager.Robots(nil)
+//	for it.Next(ctx) {
+//		robot := it.Robot()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+func (rm *RobotManager) Robots(opts *RobotListOptions) *RobotIterator {
+	var o RobotListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return &RobotIterator{axapi.NewPager(func(ctx context.Context, pageNum int) (*RobotListPage, error) {
+		o.PageNum = pageNum
+		return rm.GetRobotList(ctx, &o)
+	})}
+}
+
+// RobotIterator iterates over the robot list page by page
+type RobotIterator struct {
+	*axapi.Pager[Robot]
+}
+
+// Robot returns the robot Next advanced to
+func (it *RobotIterator) Robot() Robot {
+	return it.Item()
+}
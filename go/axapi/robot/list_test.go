@@ -0,0 +1,51 @@
+package robot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestRobotManager_GetRobotList(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"status":200,"data":{"list":[{"robotId":"r1","isOnLine":true},{"robotId":"r2"}],"total":2}}`))
+	}))
+	defer srv.Close()
+
+	manager := NewRobotManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	page, err := manager.GetRobotList(context.Background(), &RobotListOptions{
+		PageSize:   10,
+		PageNum:    3,
+		BusinessID: "b1",
+		Keyword:    "kw",
+		OnlineOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("GetRobotList() error = %v", err)
+	}
+	if len(page.List) != 1 || page.List[0].RobotID != "r1" {
+		t.Errorf("GetRobotList() = %+v, want only the online r1", page.List)
+	}
+	want := map[string]interface{}{"pageSize": 10.0, "pageNum": 3.0, "businessId": "b1", "keyWord": "kw"}
+	if !reflect.DeepEqual(bodies[0], want) {
+		t.Errorf("request body = %v, want %v", bodies[0], want)
+	}
+
+	// ListAll starts at the first page whatever PageNum says
+	robots, err := manager.ListAll(context.Background(), &RobotListOptions{PageNum: 3})
+	if err != nil || len(robots) != 2 {
+		t.Errorf("ListAll() = %d robots, %v, want 2", len(robots), err)
+	}
+	if bodies[1]["pageNum"] != 1.0 {
+		t.Errorf("ListAll() requested page %v, want 1", bodies[1]["pageNum"])
+	}
+}
@@ -0,0 +1,88 @@
+// Package robot queries the robots bound to an Autoxing account.
+package robot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+	"github.com/AutoxingTech/APIDemo/go/axapi/internal/jsonx"
+)
+
+// Robot represents a single robot's data as returned by /robot/v1.1/list
+type Robot struct {
+	RobotID         string     `json:"robotId"`
+	Name            string     `json:"name,omitempty"`
+	NickName        string     `json:"nickName,omitempty"`
+	Model           string     `json:"model,omitempty"`
+	SerialNumber    string     `json:"serialNumber,omitempty"`
+	ProductID       string     `json:"productId,omitempty"`
+	BusinessID      string     `json:"businessId,omitempty"`
+	BusinessName    string     `json:"businessName,omitempty"`
+	BuildingID      string     `json:"buildingId,omitempty"`
+	BuildingName    string     `json:"buildingName,omitempty"`
+	AreaID          string     `json:"areaId,omitempty"`
+	FirmwareVersion string     `json:"firmwareVersion,omitempty"`
+	AppVersion      string     `json:"appVersion,omitempty"`
+	IsOnLine        bool       `json:"isOnLine"`
+	CreateTime      axapi.Time `json:"createTime"`
+	UpdateTime      axapi.Time `json:"updateTime"`
+
+	// Extra holds the fields the server sent that Robot does not model
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// robotFields has the fields of Robot without its JSON methods
+type robotFields Robot
+
+// UnmarshalJSON decodes a robot, keeping unknown fields in Extra
+func (r *Robot) UnmarshalJSON(data []byte) error {
+	var f robotFields
+	if err := jsonx.UnmarshalWithExtra(data, &f, &f.Extra); err != nil {
+		return err
+	}
+	*r = Robot(f)
+	return nil
+}
+
+// MarshalJSON encodes a robot including the fields in Extra
+func (r Robot) MarshalJSON() ([]byte, error) {
+	return jsonx.MarshalWithExtra(robotFields(r), r.Extra)
+}
+
+// DisplayName returns the nickname if set, otherwise the name or ID
+func (r Robot) DisplayName() string {
+	switch {
+	case r.NickName != "":
+		return r.NickName
+	case r.Name != "":
+		return r.Name
+	}
+	return r.RobotID
+}
+
+// RobotManager handles robot-related operations
+type RobotManager struct {
+	client *axapi.Client
+}
+
+// NewRobotManager creates a new instance of RobotManager
+func NewRobotManager(client *axapi.Client) *RobotManager {
+	return &RobotManager{client: client}
+}
+
+// GetRobotState retrieves the state of a specific robot
+func (rm *RobotManager) GetRobotState(ctx context.Context, robotID string) (RobotState, error) {
+	req := &axapi.Request{
+		Method: http.MethodGet,
+		Path:   fmt.Sprintf("/robot/v1.1/%s/state", robotID),
+	}
+
+	var state RobotState
+	if err := rm.client.Do(ctx, req, &state); err != nil {
+		return RobotState{}, err
+	}
+	return state, nil
+}
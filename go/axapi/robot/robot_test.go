@@ -0,0 +1,136 @@
+package robot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+	"github.com/AutoxingTech/APIDemo/go/axapi/auth"
+)
+
+func TestAxRobot(t *testing.T) {
+	// Example configuration
+	config, err := axapi.LoadConfig("")
+	if err != nil {
+		t.Skip("API credentials not configured:", err)
+	}
+
+	client := axapi.NewClient(config)
+	tokenManager := auth.NewTokenManager(client)
+	_, err = tokenManager.GetToken(context.Background())
+
+	if err == nil {
+		// Example usage
+		manager := NewRobotManager(client.WithTokenSource(tokenManager))
+
+		// Get robot list
+		page, err := manager.GetRobotList(context.Background(), nil)
+		fmt.Printf("GetRobotList result: %v\n", err)
+		if err == nil {
+			for _, robot := range page.List {
+				fmt.Printf("Robot ID: %s, Online: %v\n", robot.RobotID, robot.IsOnLine)
+			}
+		} else {
+			fmt.Println("Get Robot List Failed")
+		}
+
+		// Get specific robot state
+		state, err := manager.GetRobotState(context.Background(), "xxxxxxxxxxxx")
+		fmt.Printf("GetRobotState result: %v, State: %+v\n", err, state)
+		return
+	}
+	t.Error("TestAxToken failed:", err)
+}
+
+func TestAxRobot_RobotList(t *testing.T) {
+	// Example configuration
+	config, err := axapi.LoadConfig("")
+	if err != nil {
+		t.Skip("API credentials not configured:", err)
+	}
+
+	client := axapi.NewClient(config)
+	tokenManager := auth.NewTokenManager(client)
+	_, err = tokenManager.GetToken(context.Background())
+
+	if err == nil {
+		// Example usage
+		manager := NewRobotManager(client.WithTokenSource(tokenManager))
+
+		// Get robot list
+		robots, err := manager.ListAll(context.Background(), nil)
+		fmt.Printf("ListAll result: %v\n", err)
+		if err == nil {
+			for _, robot := range robots {
+				fmt.Printf("Robot ID: %s, Online: %v\n", robot.RobotID, robot.IsOnLine)
+			}
+		} else {
+			fmt.Println("Get Robot List Failed")
+		}
+
+		// Get specific robot state
+		state, err := manager.GetRobotState(context.Background(), "xxxxxxxxxxxx")
+		fmt.Printf("GetRobotState result: %v, State: %+v\n", err, state)
+		return
+	}
+	t.Error("TestAxToken failed:", err)
+}
+
+func TestRobot_JSON(t *testing.T) {
+	data := []byte(`{"robotId":"r1","name":"n1","model":"AX-1","businessId":"b1","buildingId":"bd1",` +
+		`"firmwareVersion":"1.2.3","isOnLine":true,"createTime":1700000000000,` +
+		`"newField":{"a":1},"another":"x"}`)
+
+	var r Robot
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if r.RobotID != "r1" || r.Model != "AX-1" || r.BusinessID != "b1" || r.BuildingID != "bd1" ||
+		r.FirmwareVersion != "1.2.3" || !r.IsOnLine || r.CreateTime.UnixMilli() != 1700000000000 {
+		t.Errorf("Unmarshal() = %+v", r)
+	}
+	if len(r.Extra) != 2 || string(r.Extra["newField"]) != `{"a":1}` || string(r.Extra["another"]) != `"x"` {
+		t.Errorf("Extra = %v, want newField and another", r.Extra)
+	}
+	if r.DisplayName() != "n1" {
+		t.Errorf("DisplayName() = %q, want n1", r.DisplayName())
+	}
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got, want map[string]interface{}
+	json.Unmarshal(out, &got)
+	json.Unmarshal(data, &want)
+	for k, v := range want {
+		if !reflect.DeepEqual(got[k], v) {
+			t.Errorf("round trip %s = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestRobot_JSONCaseInsensitive(t *testing.T) {
+	// encoding/json fills IsOnLine from isOnline, so it is not extra
+	var r Robot
+	if err := json.Unmarshal([]byte(`{"robotId":"r1","isOnline":true}`), &r); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !r.IsOnLine || r.Extra != nil {
+		t.Errorf("Unmarshal() IsOnLine = %v, Extra = %v, want true and none", r.IsOnLine, r.Extra)
+	}
+
+	r.Extra = map[string]json.RawMessage{"isOnline": json.RawMessage("false")}
+	out, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got map[string]interface{}
+	json.Unmarshal(out, &got)
+	if _, ok := got["isOnline"]; ok || got["isOnLine"] != true {
+		t.Errorf("Marshal() = %s, want only isOnLine", out)
+	}
+}
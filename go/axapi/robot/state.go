@@ -0,0 +1,74 @@
+package robot
+
+import "github.com/AutoxingTech/APIDemo/go/axapi"
+
+// MotionState is what the robot's chassis is doing
+type MotionState string
+
+// Motion states reported in RobotState.MotionState
+const (
+	MotionIdle   MotionState = "idle"
+	MotionMoving MotionState = "moving"
+	MotionPaused MotionState = "paused"
+	MotionStuck  MotionState = "stuck"
+)
+
+// Pose is a position on the map of RobotState.AreaID, in meters and radians
+type Pose struct {
+	X   float64 `json:"x"`
+	Y   float64 `json:"y"`
+	Yaw float64 `json:"yaw"`
+}
+
+// RobotError is a fault reported by the robot
+type RobotError struct {
+	Code    int    `json:"code"`
+	Level   int    `json:"level"`
+	Message string `json:"msg"`
+}
+
+// RobotState represents the state of a robot as returned by
+// /robot/v1.1/{robotId}/state
+type RobotState struct {
+	RobotID  string `json:"robotId"`
+	IsOnLine bool   `json:"isOnLine"`
+
+	// Battery is the charge level in percent
+	Battery  int  `json:"battery"`
+	Charging bool `json:"isCharging"`
+
+	Pose
+	AreaID    string `json:"areaId"`
+	Floor     int    `json:"floor"`
+	FloorName string `json:"floorName"`
+
+	// TaskID is the task being executed, empty when there is none
+	TaskID        string      `json:"taskId"`
+	MotionState   MotionState `json:"moveState"`
+	Speed         float64     `json:"speed"`
+	EmergencyStop bool        `json:"isEmergencyStop"`
+	ManualMode    bool        `json:"isManualMode"`
+
+	Errors    []RobotError `json:"errors"`
+	Timestamp axapi.Time   `json:"timestamp"`
+}
+
+// IsCharging reports whether the robot is charging
+func (s RobotState) IsCharging() bool {
+	return s.Charging
+}
+
+// IsIdle reports whether the robot is online, has no task, is standing
+// still and is free to accept a new task
+func (s RobotState) IsIdle() bool {
+	if !s.IsOnLine || s.TaskID != "" || s.HasFault() || s.ManualMode {
+		return false
+	}
+	return s.MotionState == "" || s.MotionState == MotionIdle
+}
+
+// HasFault reports whether the robot reports errors or its emergency stop
+// is pressed
+func (s RobotState) HasFault() bool {
+	return s.EmergencyStop || len(s.Errors) > 0
+}
@@ -0,0 +1,87 @@
+package robot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestRobotManager_GetRobotState(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"status":200,"data":{"robotId":"r1","isOnLine":true,"battery":42,"isCharging":true,` +
+			`"x":1.5,"y":-2,"yaw":3.14,"areaId":"a1","floor":3,"floorName":"3F","taskId":"t1",` +
+			`"moveState":"moving","speed":0.8,"isEmergencyStop":false,` +
+			`"errors":[{"code":2001,"level":2,"msg":"lidar"}],"timestamp":1700000000000}}`))
+	}))
+	defer srv.Close()
+
+	manager := NewRobotManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	state, err := manager.GetRobotState(context.Background(), "r1")
+	if err != nil {
+		t.Fatalf("GetRobotState() error = %v", err)
+	}
+	if gotPath != "/robot/v1.1/r1/state" {
+		t.Errorf("path = %q", gotPath)
+	}
+
+	want := RobotState{
+		RobotID:     "r1",
+		IsOnLine:    true,
+		Battery:     42,
+		Charging:    true,
+		Pose:        Pose{X: 1.5, Y: -2, Yaw: 3.14},
+		AreaID:      "a1",
+		Floor:       3,
+		FloorName:   "3F",
+		TaskID:      "t1",
+		MotionState: MotionMoving,
+		Speed:       0.8,
+		Errors:      []RobotError{{Code: 2001, Level: 2, Message: "lidar"}},
+	}
+	want.Timestamp = state.Timestamp
+	if !reflect.DeepEqual(state, want) {
+		t.Errorf("GetRobotState() = %+v, want %+v", state, want)
+	}
+	if state.Timestamp.UnixMilli() != 1700000000000 {
+		t.Errorf("Timestamp = %v", state.Timestamp)
+	}
+}
+
+func TestRobotState_Helpers(t *testing.T) {
+	idle := RobotState{IsOnLine: true, MotionState: MotionIdle}
+	tests := []struct {
+		name     string
+		state    RobotState
+		charging bool
+		isIdle   bool
+		fault    bool
+	}{
+		{name: "idle", state: idle, isIdle: true},
+		{name: "idle without motion state", state: RobotState{IsOnLine: true}, isIdle: true},
+		{name: "charging idle", state: RobotState{IsOnLine: true, Charging: true}, charging: true, isIdle: true},
+		{name: "offline", state: RobotState{}, isIdle: false},
+		{name: "running task", state: RobotState{IsOnLine: true, TaskID: "t"}, isIdle: false},
+		{name: "moving", state: RobotState{IsOnLine: true, MotionState: MotionMoving}, isIdle: false},
+		{name: "emergency stop", state: RobotState{IsOnLine: true, EmergencyStop: true}, fault: true},
+		{name: "errors", state: RobotState{IsOnLine: true, Errors: []RobotError{{Code: 1}}}, fault: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.state.IsCharging(); got != tt.charging {
+				t.Errorf("IsCharging() = %v, want %v", got, tt.charging)
+			}
+			if got := tt.state.IsIdle(); got != tt.isIdle {
+				t.Errorf("IsIdle() = %v, want %v", got, tt.isIdle)
+			}
+			if got := tt.state.HasFault(); got != tt.fault {
+				t.Errorf("HasFault() = %v, want %v", got, tt.fault)
+			}
+		})
+	}
+}
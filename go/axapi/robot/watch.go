@@ -0,0 +1,284 @@
+package robot
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// EventType is the kind of change reported by Watch
+type EventType int
+
+// Event types emitted by Watch
+const (
+	// EventSnapshot carries the first state observed
+	EventSnapshot EventType = iota
+	EventOnline
+	EventOffline
+	// EventBatteryLow fires when the battery drops to or below a threshold
+	EventBatteryLow
+	// EventBatteryRecovered fires when the battery rises above a threshold
+	EventBatteryRecovered
+	EventChargingStarted
+	EventChargingStopped
+	EventPoseChanged
+	EventFaultRaised
+	EventFaultCleared
+	EventTaskStarted
+	EventTaskEnded
+	// EventError reports a failed poll; watching continues
+	EventError
+)
+
+var eventTypeNames = [...]string{
+	EventSnapshot:         "snapshot",
+	EventOnline:           "online",
+	EventOffline:          "offline",
+	EventBatteryLow:       "battery-low",
+	EventBatteryRecovered: "battery-recovered",
+	EventChargingStarted:  "charging-started",
+	EventChargingStopped:  "charging-stopped",
+	EventPoseChanged:      "pose-changed",
+	EventFaultRaised:      "fault-raised",
+	EventFaultCleared:     "fault-cleared",
+	EventTaskStarted:      "task-started",
+	EventTaskEnded:        "task-ended",
+	EventError:            "error",
+}
+
+// String returns the name of the event type
+func (t EventType) String() string {
+	if t >= 0 && int(t) < len(eventTypeNames) {
+		return eventTypeNames[t]
+	}
+	return "EventType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// Event is a change in a watched robot's state
+type Event struct {
+	Type    EventType
+	RobotID string
+	Time    time.Time
+	// State is the state in which the change was observed
+	State RobotState
+	// Previous is the state before the change; zero for EventSnapshot.
+	// For EventPoseChanged it is the state of the last pose event, so
+	// small moves add up until they cross the threshold.
+	Previous RobotState
+	// Threshold is the battery level crossed, for battery events
+	Threshold int
+	// TaskID is the task that started or ended, for task events
+	TaskID string
+	// Err is set for EventError
+	Err error
+}
+
+// Defaults used by Watch for zero WatchOptions fields
+const (
+	DefaultWatchInterval = 5 * time.Second
+	DefaultPoseDistance  = 0.1
+	DefaultPoseYaw       = 0.17
+)
+
+// DefaultBatteryThresholds are the battery levels, in percent, that trigger
+// battery events when WatchOptions.BatteryThresholds is nil
+var DefaultBatteryThresholds = []int{20, 10}
+
+// WatchOptions configures Watch
+type WatchOptions struct {
+	// Interval between polls; defaults to DefaultWatchInterval
+	Interval time.Duration
+	// BatteryThresholds defaults to DefaultBatteryThresholds
+	BatteryThresholds []int
+	// PoseDistance is the movement in meters that counts as a pose change;
+	// defaults to DefaultPoseDistance
+	PoseDistance float64
+	// PoseYaw is the rotation in radians that counts as a pose change;
+	// defaults to DefaultPoseYaw
+	PoseYaw float64
+	// Push optionally delivers states as they are pushed by the server.
+	// They are compared like polled states; polling continues as a
+	// fallback, so Interval can be raised when Push is used.
+	Push <-chan RobotState
+}
+
+func (o *WatchOptions) withDefaults() WatchOptions {
+	var w WatchOptions
+	if o != nil {
+		w = *o
+	}
+	if w.Interval <= 0 {
+		w.Interval = DefaultWatchInterval
+	}
+	if w.BatteryThresholds == nil {
+		w.BatteryThresholds = DefaultBatteryThresholds
+	}
+	if w.PoseDistance <= 0 {
+		w.PoseDistance = DefaultPoseDistance
+	}
+	if w.PoseYaw <= 0 {
+		w.PoseYaw = DefaultPoseYaw
+	}
+	return w
+}
+
+// Watch polls the state of robotID and emits an event for every change.
+// The first state is delivered as EventSnapshot. The channel is closed
+// once ctx is done; the caller must keep receiving until then.
+func (rm *RobotManager) Watch(ctx context.Context, robotID string, opts *WatchOptions) <-chan Event {
+	o := opts.withDefaults()
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(o.Interval)
+		defer ticker.Stop()
+
+		// prev is the last state observed and pose the one of the last
+		// pose event (or the snapshot)
+		var prev, pose *RobotState
+		emit := func(evs []Event) bool {
+			for _, ev := range evs {
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		}
+		observe := func(state RobotState) bool {
+			evs := diffStates(prev, pose, state, &o)
+			for i := range evs {
+				evs[i].RobotID = robotID
+				evs[i].Time = time.Now()
+				if evs[i].Type == EventSnapshot || evs[i].Type == EventPoseChanged {
+					pose = &state
+				}
+			}
+			prev = &state
+			return emit(evs)
+		}
+		poll := func() bool {
+			state, err := rm.GetRobotState(ctx, robotID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return false
+				}
+				if errors.Is(err, axapi.ErrRobotOffline) && prev != nil {
+					// The last known state, marked offline
+					offline := *prev
+					offline.IsOnLine = false
+					return observe(offline)
+				}
+				return emit([]Event{{Type: EventError, RobotID: robotID, Time: time.Now(), Err: err}})
+			}
+			return observe(state)
+		}
+
+		if !poll() {
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !poll() {
+					return
+				}
+			case state, ok := <-o.Push:
+				if !ok {
+					o.Push = nil
+					continue
+				}
+				if !observe(state) {
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// diffStates returns the events describing the change from prev to cur.
+// The pose is compared with pose, the state of the last pose event, rather
+// than prev. A nil prev yields a single EventSnapshot.
+func diffStates(prev, pose *RobotState, cur RobotState, o *WatchOptions) []Event {
+	if prev == nil {
+		return []Event{{Type: EventSnapshot, State: cur}}
+	}
+	p := *prev
+	var evs []Event
+	add := func(ev Event) {
+		ev.State, ev.Previous = cur, p
+		evs = append(evs, ev)
+	}
+
+	if p.IsOnLine != cur.IsOnLine {
+		if cur.IsOnLine {
+			add(Event{Type: EventOnline})
+		} else {
+			add(Event{Type: EventOffline})
+		}
+	}
+
+	for _, th := range o.BatteryThresholds {
+		switch {
+		case p.Battery > th && cur.Battery <= th:
+			add(Event{Type: EventBatteryLow, Threshold: th})
+		case p.Battery <= th && cur.Battery > th:
+			add(Event{Type: EventBatteryRecovered, Threshold: th})
+		}
+	}
+
+	if p.Charging != cur.Charging {
+		if cur.Charging {
+			add(Event{Type: EventChargingStarted})
+		} else {
+			add(Event{Type: EventChargingStopped})
+		}
+	}
+
+	if ref := *pose; ref.AreaID != cur.AreaID ||
+		math.Hypot(cur.X-ref.X, cur.Y-ref.Y) >= o.PoseDistance ||
+		math.Abs(angleDiff(cur.Yaw, ref.Yaw)) >= o.PoseYaw {
+		evs = append(evs, Event{Type: EventPoseChanged, State: cur, Previous: ref})
+	}
+
+	if p.HasFault() != cur.HasFault() {
+		if cur.HasFault() {
+			add(Event{Type: EventFaultRaised})
+		} else {
+			add(Event{Type: EventFaultCleared})
+		}
+	}
+
+	if p.TaskID != cur.TaskID {
+		if p.TaskID != "" {
+			add(Event{Type: EventTaskEnded, TaskID: p.TaskID})
+		}
+		if cur.TaskID != "" {
+			add(Event{Type: EventTaskStarted, TaskID: cur.TaskID})
+		}
+	}
+	return evs
+}
+
+// angleDiff returns a-b normalized to [-π, π]
+func angleDiff(a, b float64) float64 {
+	d := math.Mod(a-b, 2*math.Pi)
+	switch {
+	case d > math.Pi:
+		d -= 2 * math.Pi
+	case d < -math.Pi:
+		d += 2 * math.Pi
+	}
+	return d
+}
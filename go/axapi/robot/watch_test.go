@@ -0,0 +1,190 @@
+package robot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func eventTypes(evs []Event) []EventType {
+	var types []EventType
+	for _, ev := range evs {
+		types = append(types, ev.Type)
+	}
+	return types
+}
+
+func TestDiffStates(t *testing.T) {
+	o := (&WatchOptions{}).withDefaults()
+	base := RobotState{IsOnLine: true, Battery: 50, Pose: Pose{X: 1, Y: 1}, AreaID: "a"}
+	tests := []struct {
+		name   string
+		change func(s *RobotState)
+		want   []EventType
+	}{
+		{name: "no change", change: func(s *RobotState) {}},
+		{name: "jitter below threshold", change: func(s *RobotState) { s.X += 0.01; s.Yaw += 0.01 }},
+		{name: "offline", change: func(s *RobotState) { s.IsOnLine = false }, want: []EventType{EventOffline}},
+		{name: "battery low", change: func(s *RobotState) { s.Battery = 20 }, want: []EventType{EventBatteryLow}},
+		{name: "battery two thresholds", change: func(s *RobotState) { s.Battery = 5 }, want: []EventType{EventBatteryLow, EventBatteryLow}},
+		{name: "charging", change: func(s *RobotState) { s.Charging = true }, want: []EventType{EventChargingStarted}},
+		{name: "moved", change: func(s *RobotState) { s.X += 0.5 }, want: []EventType{EventPoseChanged}},
+		{name: "rotated across pi", change: func(s *RobotState) { s.Yaw = 3.1 }, want: []EventType{EventPoseChanged}},
+		{name: "changed area", change: func(s *RobotState) { s.AreaID = "b" }, want: []EventType{EventPoseChanged}},
+		{name: "fault", change: func(s *RobotState) { s.EmergencyStop = true }, want: []EventType{EventFaultRaised}},
+		{name: "task started", change: func(s *RobotState) { s.TaskID = "t1" }, want: []EventType{EventTaskStarted}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := base
+			tt.change(&cur)
+			if got := eventTypes(diffStates(&base, &base, cur, &o)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffStates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	prev := RobotState{IsOnLine: true, Battery: 15, TaskID: "t1", Errors: []RobotError{{Code: 1}}, Pose: Pose{Yaw: 3.1}}
+	cur := RobotState{IsOnLine: true, Battery: 30, TaskID: "t2", Pose: Pose{Yaw: -3.1}}
+	evs := diffStates(&prev, &prev, cur, &o)
+	want := []EventType{EventBatteryRecovered, EventFaultCleared, EventTaskEnded, EventTaskStarted}
+	if got := eventTypes(evs); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffStates() = %v, want %v", got, want)
+	}
+	if evs[0].Threshold != 20 || evs[2].TaskID != "t1" || evs[3].TaskID != "t2" {
+		t.Errorf("diffStates() details = %+v", evs)
+	}
+
+	if got := eventTypes(diffStates(nil, nil, cur, &o)); !reflect.DeepEqual(got, []EventType{EventSnapshot}) {
+		t.Errorf("diffStates(nil) = %v, want snapshot", got)
+	}
+
+	// small steps are measured from the last pose event, not the last state
+	ref := base
+	step := base
+	step.X += 0.06
+	if got := eventTypes(diffStates(&base, &ref, step, &o)); got != nil {
+		t.Errorf("diffStates(first step) = %v, want none", got)
+	}
+	next := step
+	next.X += 0.06
+	evs = diffStates(&step, &ref, next, &o)
+	if got := eventTypes(evs); !reflect.DeepEqual(got, []EventType{EventPoseChanged}) {
+		t.Errorf("diffStates(second step) = %v, want pose changed", got)
+	} else if evs[0].Previous.X != base.X {
+		t.Errorf("pose event Previous.X = %v, want %v", evs[0].Previous.X, base.X)
+	}
+}
+
+func TestRobotManager_Watch(t *testing.T) {
+	var mu sync.Mutex
+	battery := 25
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		b := battery
+		battery -= 5
+		mu.Unlock()
+		if b <= 10 {
+			w.Write([]byte(`{"status":500,"message":"robot is offline"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"status":200,"data":{"robotId":"r1","isOnLine":true,"battery":%d}}`, b)
+	}))
+	defer srv.Close()
+
+	manager := NewRobotManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}, axapi.WithRetryPolicy(axapi.NoRetry)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := manager.Watch(ctx, "r1", &WatchOptions{Interval: 5 * time.Millisecond})
+
+	var got []EventType
+	for ev := range events {
+		if ev.RobotID != "r1" {
+			t.Errorf("event RobotID = %q", ev.RobotID)
+		}
+		got = append(got, ev.Type)
+		if ev.Type == EventOffline {
+			cancel()
+		}
+	}
+	// 25 -> 20 -> 15 -> offline
+	want := []EventType{EventSnapshot, EventBatteryLow, EventOffline}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestRobotManager_WatchPush(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":200,"data":{"robotId":"r1","isOnLine":true,"battery":80}}`))
+	}))
+	defer srv.Close()
+
+	push := make(chan RobotState, 1)
+	manager := NewRobotManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := manager.Watch(ctx, "r1", &WatchOptions{Interval: time.Hour, Push: push})
+
+	if ev := <-events; ev.Type != EventSnapshot {
+		t.Fatalf("first event = %v, want snapshot", ev.Type)
+	}
+	push <- RobotState{RobotID: "r1", IsOnLine: true, Battery: 80, Charging: true}
+	if ev := <-events; ev.Type != EventChargingStarted {
+		t.Errorf("pushed event = %v, want charging-started", ev.Type)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestRobotManager_WatchSmallMoves(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":200,"data":{"robotId":"r1","isOnLine":true,"battery":80}}`))
+	}))
+	defer srv.Close()
+
+	push := make(chan RobotState)
+	manager := NewRobotManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := manager.Watch(ctx, "r1", &WatchOptions{Interval: time.Hour, Push: push})
+	if ev := <-events; ev.Type != EventSnapshot {
+		t.Fatalf("first event = %v, want snapshot", ev.Type)
+	}
+
+	var got []EventType
+	go func() {
+		// 0.04 m steps: only the third crosses the 0.1 m threshold
+		for i := 1; i <= 5; i++ {
+			push <- RobotState{RobotID: "r1", IsOnLine: true, Battery: 80, Pose: Pose{X: 0.04 * float64(i)}}
+		}
+		push <- RobotState{RobotID: "r1", IsOnLine: true, Battery: 80, Charging: true, Pose: Pose{X: 0.2}}
+	}()
+	for ev := range events {
+		got = append(got, ev.Type)
+		if ev.Type == EventChargingStarted {
+			cancel()
+		}
+	}
+	if want := []EventType{EventPoseChanged, EventChargingStarted}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestEventType_String(t *testing.T) {
+	if s := EventBatteryLow.String(); s != "battery-low" {
+		t.Errorf("String() = %q", s)
+	}
+	if s := EventType(99).String(); s != "EventType(99)" {
+		t.Errorf("String() = %q", s)
+	}
+}
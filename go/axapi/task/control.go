@@ -0,0 +1,80 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+	"github.com/AutoxingTech/APIDemo/go/axapi/internal/jsonx"
+)
+
+// ControlAction is an operation on a running task
+type ControlAction string
+
+// Task control actions; each is also the last segment of its endpoint
+const (
+	ControlCancel ControlAction = "cancel"
+	ControlPause  ControlAction = "pause"
+	ControlResume ControlAction = "resume"
+	ControlGoNext ControlAction = "goNext"
+)
+
+// ControlResult is the answer to a task control call
+type ControlResult struct {
+	TaskID string        `json:"taskId"`
+	Action ControlAction `json:"-"`
+
+	// Extra holds any other fields the server returned
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// CancelTask cancels a task
+func (tm *TaskManager) CancelTask(ctx context.Context, taskId string) (ControlResult, error) {
+	return tm.control(ctx, taskId, ControlCancel)
+}
+
+// PauseTask pauses a running task; the robot stops where it is
+func (tm *TaskManager) PauseTask(ctx context.Context, taskId string) (ControlResult, error) {
+	return tm.control(ctx, taskId, ControlPause)
+}
+
+// ResumeTask resumes a paused task
+func (tm *TaskManager) ResumeTask(ctx context.Context, taskId string) (ControlResult, error) {
+	return tm.control(ctx, taskId, ControlResume)
+}
+
+// GoNextPoint makes a task leave its current point, e.g. a robot held at
+// a wait action, and head for the next one
+func (tm *TaskManager) GoNextPoint(ctx context.Context, taskId string) (ControlResult, error) {
+	return tm.control(ctx, taskId, ControlGoNext)
+}
+
+// control posts action for taskId
+func (tm *TaskManager) control(ctx context.Context, taskId string, action ControlAction) (ControlResult, error) {
+	req := &axapi.Request{
+		Method: http.MethodPost,
+		Path:   fmt.Sprintf("/task/v1.1/%s/%s", taskId, action),
+		// repeating any of these but goNext leaves the task as it was
+		Idempotent: action != ControlGoNext,
+	}
+
+	var data json.RawMessage
+	if err := tm.client.Do(ctx, req, &data); err != nil {
+		return ControlResult{}, err
+	}
+
+	var result ControlResult
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		if err := jsonx.UnmarshalWithExtra(data, &result, &result.Extra); err != nil {
+			return ControlResult{}, fmt.Errorf("parsing %s result: %w", action, err)
+		}
+	}
+	if result.TaskID == "" {
+		result.TaskID = taskId
+	}
+	result.Action = action
+	return result, nil
+}
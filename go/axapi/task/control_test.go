@@ -0,0 +1,65 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestTaskManager_Control(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/task/v1.1/t1/pause":
+			w.Write([]byte(`{"status":200,"data":{"taskId":"t1","isPause":true}}`))
+		case "/task/v1.1/gone/cancel":
+			w.Write([]byte(`{"status":404,"message":"task not found"}`))
+		default:
+			w.Write([]byte(`{"status":200,"data":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	manager := NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(context.Context, string) (ControlResult, error)
+		want ControlResult
+	}{
+		{name: "cancel", call: manager.CancelTask, want: ControlResult{TaskID: "t1", Action: ControlCancel}},
+		{name: "pause", call: manager.PauseTask, want: ControlResult{
+			TaskID: "t1",
+			Action: ControlPause,
+			Extra:  map[string]json.RawMessage{"isPause": json.RawMessage("true")},
+		}},
+		{name: "resume", call: manager.ResumeTask, want: ControlResult{TaskID: "t1", Action: ControlResume}},
+		{name: "go next", call: manager.GoNextPoint, want: ControlResult{TaskID: "t1", Action: ControlGoNext}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths = nil
+			got, err := tt.call(ctx, "t1")
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result = %+v, want %+v", got, tt.want)
+			}
+			if want := []string{"POST /task/v1.1/t1/" + string(tt.want.Action)}; !reflect.DeepEqual(paths, want) {
+				t.Errorf("calls = %v, want %v", paths, want)
+			}
+		})
+	}
+
+	if _, err := manager.CancelTask(ctx, "gone"); !errors.Is(err, axapi.ErrNotFound) {
+		t.Errorf("CancelTask() error = %v, want ErrNotFound", err)
+	}
+}
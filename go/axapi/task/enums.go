@@ -0,0 +1,150 @@
+package task
+
+import "strconv"
+
+// TaskType is the kind of task, sent as taskType
+type TaskType int
+
+// Task types
+const (
+	TaskTypeDisinfection TaskType = 0
+	TaskTypeReturn       TaskType = 1
+	TaskTypeGuide        TaskType = 2
+	TaskTypePatrol       TaskType = 3
+	TaskTypeDelivery     TaskType = 4
+	TaskTypeLift         TaskType = 5
+)
+
+var taskTypeNames = map[TaskType]string{
+	TaskTypeDisinfection: "disinfection",
+	TaskTypeReturn:       "return",
+	TaskTypeGuide:        "guide",
+	TaskTypePatrol:       "patrol",
+	TaskTypeDelivery:     "delivery",
+	TaskTypeLift:         "lift",
+}
+
+// String returns the name of the task type
+func (t TaskType) String() string {
+	return enumString(taskTypeNames, t, "TaskType")
+}
+
+// RunType refines the task type, sent as runType
+type RunType int
+
+// Run types
+const (
+	RunTypeScheduledDisinfection RunType = 0
+	RunTypeTemporaryDisinfection RunType = 1
+	RunTypeQuickDelivery         RunType = 20
+	RunTypeMultiPointDelivery    RunType = 21
+	RunTypeDirectDelivery        RunType = 22
+	RunTypePatrol                RunType = 23
+	RunTypeReturn                RunType = 24
+	RunTypeCharging              RunType = 25
+	RunTypeCall                  RunType = 26
+	RunTypeGuide                 RunType = 27
+	RunTypeLift                  RunType = 29
+)
+
+var runTypeNames = map[RunType]string{
+	RunTypeScheduledDisinfection: "scheduled-disinfection",
+	RunTypeTemporaryDisinfection: "temporary-disinfection",
+	RunTypeQuickDelivery:         "quick-delivery",
+	RunTypeMultiPointDelivery:    "multi-point-delivery",
+	RunTypeDirectDelivery:        "direct-delivery",
+	RunTypePatrol:                "patrol",
+	RunTypeReturn:                "return",
+	RunTypeCharging:              "charging",
+	RunTypeCall:                  "call",
+	RunTypeGuide:                 "guide",
+	RunTypeLift:                  "lift",
+}
+
+// String returns the name of the run type
+func (t RunType) String() string {
+	return enumString(runTypeNames, t, "RunType")
+}
+
+// RouteMode decides the order the task points are visited in, sent as
+// routeMode
+type RouteMode int
+
+// Route modes
+const (
+	// RouteSequential visits the points in the order they were added
+	RouteSequential RouteMode = 1
+	// RouteShortest reorders the points for the shortest route
+	RouteShortest RouteMode = 2
+)
+
+var routeModeNames = map[RouteMode]string{
+	RouteSequential: "sequential",
+	RouteShortest:   "shortest",
+}
+
+// String returns the name of the route mode
+func (m RouteMode) String() string {
+	return enumString(routeModeNames, m, "RouteMode")
+}
+
+// RunMode is how the robot moves between points, sent as runMode
+type RunMode int
+
+// Run modes
+const (
+	// RunFlexible avoids obstacles freely
+	RunFlexible RunMode = 1
+	// RunTrack follows the track and waits at obstacles
+	RunTrack RunMode = 2
+	// RunTrackAvoid follows the track but may leave it around obstacles
+	RunTrackAvoid RunMode = 3
+)
+
+var runModeNames = map[RunMode]string{
+	RunFlexible:   "flexible",
+	RunTrack:      "track",
+	RunTrackAvoid: "track-avoid",
+}
+
+// String returns the name of the run mode
+func (m RunMode) String() string {
+	return enumString(runModeNames, m, "RunMode")
+}
+
+// SourceType records where a task was created, sent as sourceType
+type SourceType int
+
+// Source types
+const (
+	SourceUnknown  SourceType = 0
+	SourceHeadApp  SourceType = 1
+	SourcePadApp   SourceType = 2
+	SourcePager    SourceType = 3
+	SourceChassis  SourceType = 4
+	SourceWebAdmin SourceType = 5
+	SourceOpenAPI  SourceType = 6
+)
+
+var sourceTypeNames = map[SourceType]string{
+	SourceUnknown:  "unknown",
+	SourceHeadApp:  "head-app",
+	SourcePadApp:   "pad-app",
+	SourcePager:    "pager",
+	SourceChassis:  "chassis",
+	SourceWebAdmin: "web-admin",
+	SourceOpenAPI:  "open-api",
+}
+
+// String returns the name of the source type
+func (t SourceType) String() string {
+	return enumString(sourceTypeNames, t, "SourceType")
+}
+
+// enumString returns the name of v, or typ(v) for values without one
+func enumString[T ~int](names map[T]string, v T, typ string) string {
+	if name, ok := names[v]; ok {
+		return name
+	}
+	return typ + "(" + strconv.Itoa(int(v)) + ")"
+}
@@ -0,0 +1,47 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestEnums_String(t *testing.T) {
+	tests := []struct {
+		v    fmt.Stringer
+		want string
+	}{
+		{TaskTypeDelivery, "delivery"},
+		{TaskType(42), "TaskType(42)"},
+		{RunTypeMultiPointDelivery, "multi-point-delivery"},
+		{RunType(2), "RunType(2)"},
+		{RouteShortest, "shortest"},
+		{RunTrack, "track"},
+		{SourceOpenAPI, "open-api"},
+	}
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestNewTaskBuilder_Options(t *testing.T) {
+	b := NewTaskBuilder("patrol", "r1", WithTaskType(TaskTypePatrol), WithRunType(RunTypePatrol))
+	b.Apply(WithRouteMode(RouteShortest), WithRunMode(RunTrack), WithSourceType(SourceWebAdmin))
+	task := b.GetTask()
+	if task.TaskType != TaskTypePatrol || task.RunType != RunTypePatrol || task.RouteMode != RouteShortest ||
+		task.RunMode != RunTrack || task.SourceType != SourceWebAdmin {
+		t.Errorf("GetTask() = %+v", task)
+	}
+
+	// the defaults keep the numbers the API examples use
+	data, _ := json.Marshal(NewTaskBuilder("d", "r1").GetTask())
+	var m map[string]interface{}
+	json.Unmarshal(data, &m)
+	for k, want := range map[string]float64{"routeMode": 1, "runMode": 1, "taskType": 4, "runType": 21, "sourceType": 6} {
+		if m[k] != want {
+			t.Errorf("%s = %v, want %v", k, m[k], want)
+		}
+	}
+}
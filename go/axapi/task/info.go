@@ -0,0 +1,82 @@
+package task
+
+import (
+	"encoding/json"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+	"github.com/AutoxingTech/APIDemo/go/axapi/internal/jsonx"
+)
+
+// Status is the execution state of a task
+type Status string
+
+// Task states
+const (
+	StatusCreated   Status = "created"
+	StatusExecuting Status = "executing"
+	StatusFinished  Status = "finished"
+	StatusCancelled Status = "cancelled"
+	StatusFailed    Status = "failed"
+)
+
+// Done reports whether the task has stopped for good
+func (s Status) Done() bool {
+	return s == StatusFinished || s == StatusCancelled || s == StatusFailed
+}
+
+// TaskInfo is a task as returned by GetTaskInfo and GetTaskList
+type TaskInfo struct {
+	Task
+	TaskID string `json:"taskId"`
+
+	// The spelling of isExcute is the server's
+	IsExcute bool `json:"isExcute"`
+	IsFinish bool `json:"isFinish"`
+	IsCancel bool `json:"isCancel"`
+	// CurrentIndex is the index in Task.TaskPts of the point being headed for
+	CurrentIndex int `json:"curPtIndex"`
+	// ErrorCode and ErrorMsg are set when the task ended on a failure
+	ErrorCode int    `json:"errCode,omitempty"`
+	ErrorMsg  string `json:"errMsg,omitempty"`
+
+	CreateTime axapi.Time `json:"createTime"`
+	StartTime  axapi.Time `json:"startTime"`
+	EndTime    axapi.Time `json:"endTime"`
+	UpdateTime axapi.Time `json:"updateTime"`
+
+	// Extra holds the fields the server sent that TaskInfo does not model
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// taskInfoFields has the fields of TaskInfo without its JSON methods
+type taskInfoFields TaskInfo
+
+// UnmarshalJSON decodes a task, keeping unknown fields in Extra
+func (t *TaskInfo) UnmarshalJSON(data []byte) error {
+	var f taskInfoFields
+	if err := jsonx.UnmarshalWithExtra(data, &f, &f.Extra); err != nil {
+		return err
+	}
+	*t = TaskInfo(f)
+	return nil
+}
+
+// MarshalJSON encodes a task including the fields in Extra
+func (t TaskInfo) MarshalJSON() ([]byte, error) {
+	return jsonx.MarshalWithExtra(taskInfoFields(t), t.Extra)
+}
+
+// Status derives the state of the task from its flags
+func (t TaskInfo) Status() Status {
+	switch {
+	case t.IsCancel:
+		return StatusCancelled
+	case t.ErrorCode != 0 || t.ErrorMsg != "":
+		return StatusFailed
+	case t.IsFinish:
+		return StatusFinished
+	case t.IsExcute:
+		return StatusExecuting
+	}
+	return StatusCreated
+}
@@ -0,0 +1,80 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestTaskInfo_Status(t *testing.T) {
+	tests := []struct {
+		info TaskInfo
+		want Status
+	}{
+		{TaskInfo{}, StatusCreated},
+		{TaskInfo{IsExcute: true}, StatusExecuting},
+		{TaskInfo{IsExcute: true, IsFinish: true}, StatusFinished},
+		{TaskInfo{IsExcute: true, IsCancel: true}, StatusCancelled},
+		{TaskInfo{IsFinish: true, ErrorCode: 3}, StatusFailed},
+		{TaskInfo{IsCancel: true, ErrorMsg: "blocked"}, StatusCancelled},
+	}
+	for _, tt := range tests {
+		if got := tt.info.Status(); got != tt.want {
+			t.Errorf("%+v.Status() = %v, want %v", tt.info, got, tt.want)
+		}
+	}
+	if StatusExecuting.Done() || !StatusFailed.Done() {
+		t.Error("Done() reports the wrong states as final")
+	}
+}
+
+func TestTaskManager_GetTaskInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/task/v1.1/t1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"status":200,"data":{
+			"taskId":"t1","name":"demo","robotId":"r1","runNum":1,"businessId":"b1",
+			"isExcute":true,"isFinish":false,"isCancel":false,"curPtIndex":1,
+			"createTime":1700000000000,
+			"taskPts":[
+				{"areaId":"a","x":1,"y":2,"type":0,"stopRadius":1,"ext":{"name":"m1"},"stepActs":[]},
+				{"areaId":"a","x":3,"y":4,"yaw":90,"type":0,"stopRadius":1,"stepActs":[{"type":40,"data":{"userData":{"cmd":"test"}}}]}
+			],
+			"backPt":{"areaId":"a","x":0,"y":0,"type":0,"stopRadius":1,"stepActs":[]}
+		}}`))
+	}))
+	defer srv.Close()
+
+	manager := NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	info, err := manager.GetTaskInfo(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("GetTaskInfo() error = %v", err)
+	}
+	if info.Status() != StatusExecuting || info.CurrentIndex != 1 || info.RobotID != "r1" {
+		t.Errorf("GetTaskInfo() = %+v", info)
+	}
+	if len(info.TaskPts) != 2 || info.TaskPts[0].Name() != "m1" || info.TaskPts[1].StepActs[0].Type != 40 || info.BackPt == nil {
+		t.Errorf("GetTaskInfo() points = %+v, back %+v", info.TaskPts, info.BackPt)
+	}
+	if info.CreateTime.UnixMilli() != 1700000000000 || !info.EndTime.IsZero() {
+		t.Errorf("GetTaskInfo() times = %v, %v", info.CreateTime, info.EndTime)
+	}
+	if info.RunNum != 1 || string(info.Extra["businessId"]) != `"b1"` || len(info.Extra) != 1 {
+		t.Errorf("GetTaskInfo() RunNum = %d, Extra = %v, want 1 and businessId kept", info.RunNum, info.Extra)
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var again TaskInfo
+	if err := json.Unmarshal(data, &again); err != nil || again.Name != "demo" || string(again.Extra["businessId"]) != `"b1"` {
+		t.Errorf("round trip = %+v, %v", again, err)
+	}
+}
@@ -0,0 +1,83 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// DefaultPageSize is the page size used when TaskListOptions.PageSize is 0
+const DefaultPageSize = axapi.DefaultPageSize
+
+// TaskListOptions pages and filters GetTaskList
+type TaskListOptions struct {
+	// PageSize defaults to DefaultPageSize
+	PageSize int
+	// PageNum starts at 1, which is also the default
+	PageNum int
+	RobotID string
+	// From and To limit the creation time of the tasks; zero means no limit
+	From time.Time
+	To   time.Time
+	// Status, if set, only lists tasks in that state. It is derived from
+	// the task flags after each page is fetched, like
+	// robot.RobotListOptions.OnlineOnly.
+	Status Status
+}
+
+// TaskListPage is one page of GetTaskList
+type TaskListPage = axapi.Page[TaskInfo]
+
+// GetTaskList retrieves one page of the tasks matching opts, newest first.
+// A nil opts fetches the first page with the default size.
+func (tm *TaskManager) GetTaskList(ctx context.Context, opts *TaskListOptions) (*TaskListPage, error) {
+	var o TaskListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	body := map[string]interface{}{}
+	if o.RobotID != "" {
+		body["robotId"] = o.RobotID
+	}
+	if !o.From.IsZero() {
+		body["startTime"] = o.From.UnixMilli()
+	}
+	if !o.To.IsZero() {
+		body["endTime"] = o.To.UnixMilli()
+	}
+	req := axapi.PageRequest[TaskInfo]{
+		Path:     "/task/v1.1/list",
+		PageSize: o.PageSize,
+		PageNum:  o.PageNum,
+		Body:     body,
+	}
+	if o.Status != "" {
+		req.Keep = func(info TaskInfo) bool { return info.Status() == o.Status }
+	}
+	return axapi.GetPage(ctx, tm.client, req)
+}
+
+// Tasks returns an iterator over all tasks matching opts, e.g. the
+// history of one robot. opts.PageNum is ignored.
+func (tm *TaskManager) Tasks(opts *TaskListOptions) *TaskIterator {
+	var o TaskListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return &TaskIterator{axapi.NewPager(func(ctx context.Context, pageNum int) (*TaskListPage, error) {
+		o.PageNum = pageNum
+		return tm.GetTaskList(ctx, &o)
+	})}
+}
+
+// TaskIterator iterates over the task list page by page
+type TaskIterator struct {
+	*axapi.Pager[TaskInfo]
+}
+
+// Task returns the task Next advanced to
+func (it *TaskIterator) Task() TaskInfo {
+	return it.Item()
+}
@@ -0,0 +1,69 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestTaskManager_GetTaskList(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"status":200,"data":{"list":[` +
+			`{"taskId":"t0","isExcute":true},{"taskId":"t1","isExcute":true,"isFinish":true},` +
+			`{"taskId":"t2"},{"taskId":"t3","isExcute":true}],"total":4}}`))
+	}))
+	defer srv.Close()
+
+	manager := NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	from := time.UnixMilli(1700000000000)
+	page, err := manager.GetTaskList(context.Background(), &TaskListOptions{
+		PageSize: 5,
+		RobotID:  "r1",
+		From:     from,
+		Status:   StatusExecuting,
+	})
+	if err != nil {
+		t.Fatalf("GetTaskList() error = %v", err)
+	}
+	// the finished t1 and the created t2 are dropped
+	var ids []string
+	for _, info := range page.List {
+		ids = append(ids, info.TaskID)
+	}
+	if !reflect.DeepEqual(ids, []string{"t0", "t3"}) || page.Total != 4 {
+		t.Errorf("GetTaskList() = %v, total %d, want [t0 t3], 4", ids, page.Total)
+	}
+	// the status is not sent to the server
+	want := map[string]interface{}{
+		"pageSize":  5.0,
+		"pageNum":   1.0,
+		"robotId":   "r1",
+		"startTime": 1700000000000.0,
+	}
+	if !reflect.DeepEqual(bodies[0], want) {
+		t.Errorf("request body = %v, want %v", bodies[0], want)
+	}
+
+	// the iterator keeps the filters and starts at the first page
+	it := manager.Tasks(&TaskListOptions{PageNum: 3, RobotID: "r1"})
+	n := 0
+	for it.Next(context.Background()) {
+		n++
+	}
+	if err := it.Err(); err != nil || n != 4 {
+		t.Errorf("Tasks() iterated %d tasks, %v, want 4", n, err)
+	}
+	if bodies[1]["pageNum"] != 1.0 || bodies[1]["robotId"] != "r1" {
+		t.Errorf("Tasks() request body = %v", bodies[1])
+	}
+}
@@ -0,0 +1,71 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// TaskManager handles task operations
+type TaskManager struct {
+	client *axapi.Client
+}
+
+// NewTaskManager creates a new task manager
+func NewTaskManager(client *axapi.Client) *TaskManager {
+	return &TaskManager{client: client}
+}
+
+// GetTaskInfo retrieves task information
+func (tm *TaskManager) GetTaskInfo(ctx context.Context, taskId string) (TaskInfo, error) {
+	req := &axapi.Request{
+		Method: http.MethodGet,
+		Path:   fmt.Sprintf("/task/v1.1/%s", taskId),
+	}
+
+	var info TaskInfo
+	if err := tm.client.Do(ctx, req, &info); err != nil {
+		return TaskInfo{}, err
+	}
+	return info, nil
+}
+
+// ExecuteTask executes a task
+func (tm *TaskManager) ExecuteTask(ctx context.Context, taskId string) error {
+	req := &axapi.Request{
+		Method: http.MethodPost,
+		Path:   fmt.Sprintf("/task/v1.1/%s/execute", taskId),
+	}
+
+	return tm.client.Do(ctx, req, nil)
+}
+
+// NewTask creates a new task. The task is checked with Validate first and
+// not sent if it has problems.
+func (tm *TaskManager) NewTask(ctx context.Context, task *Task) (string, error) {
+	if err := task.Validate(); err != nil {
+		return "", err
+	}
+	return tm.NewTaskUnchecked(ctx, task)
+}
+
+// NewTaskUnchecked creates a new task without calling Validate, for tasks
+// the server accepts but Validate does not, e.g. with enum values this
+// package does not know. Problems are then reported by the server.
+func (tm *TaskManager) NewTaskUnchecked(ctx context.Context, task *Task) (string, error) {
+	req := &axapi.Request{
+		Method: http.MethodPost,
+		Path:   "/task/v1.1",
+		Body:   task,
+	}
+
+	var data struct {
+		TaskId string `json:"taskId"`
+	}
+	if err := tm.client.Do(ctx, req, &data); err != nil {
+		return "", err
+	}
+	return data.TaskId, nil
+}
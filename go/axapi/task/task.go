@@ -0,0 +1,419 @@
+// Package task builds, submits and executes robot tasks.
+package task
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi/mapinfo"
+)
+
+// Action types and builders
+// type ActionType struct{}
+
+type ActionType struct {
+	Type int                    `json:"type"`
+	Data map[string]interface{} `json:"data"`
+}
+
+var Action = ActionType{}
+
+// Step action type codes
+const (
+	ActionPlayAudio = 5
+	ActionPause     = 18
+	ActionWait      = 40
+	ActionLiftUp    = 47
+	ActionLiftDown  = 48
+)
+
+// PauseAction creates a pause action
+func (a ActionType) PauseAction(duration int) ActionType {
+	return ActionType{ActionPause, map[string]interface{}{
+		"pauseTime": duration,
+	}}
+}
+
+// PlayAudioAction creates a play audio action
+func (a ActionType) PlayAudioAction(audioId string) ActionType {
+	return ActionType{
+		ActionPlayAudio,
+		map[string]interface{}{
+			"mode":     1,
+			"url":      "",
+			"audioId":  audioId,
+			"interval": -1,
+			"num":      1,
+			"volume":   100,
+			"channel":  1,
+			"duration": -1,
+		},
+	}
+}
+
+// WaitAction creates a wait action
+func (a ActionType) WaitAction(userData interface{}) ActionType {
+	return ActionType{
+		ActionWait,
+		map[string]interface{}{
+			"userData": userData,
+		},
+	}
+}
+
+func (a ActionType) LiftUp(useAreaId *string) ActionType {
+
+	attrs := map[string]interface{}{}
+
+	if useAreaId != nil {
+		attrs["useAreaId"] = *useAreaId
+	}
+
+	return ActionType{
+		ActionLiftUp,
+		attrs,
+	}
+}
+
+func (a ActionType) LiftDown(useAreaId *string) ActionType {
+
+	attrs := map[string]interface{}{}
+
+	if useAreaId != nil {
+		attrs["useAreaId"] = *useAreaId
+	}
+
+	return ActionType{
+		ActionLiftDown,
+		attrs,
+	}
+}
+
+// POI represents a point of interest, as returned by mapinfo.MapInfoManager
+type POI = mapinfo.POI
+
+// PointExt holds the extra data of a task point
+type PointExt struct {
+	Name string `json:"name,omitempty"`
+}
+
+// TaskPoint represents a point in the task
+type TaskPoint struct {
+	AreaID string  `json:"areaId"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	// Yaw is the heading at the point; nil lets the robot keep its own
+	Yaw        *float64     `json:"yaw,omitempty"`
+	Type       int          `json:"type"`
+	StopRadius float64      `json:"stopRadius"`
+	Ext        PointExt     `json:"ext"`
+	StepActs   []ActionType `json:"stepActs"`
+
+	// err is set by NewTaskPoint for a POI it could not use
+	err error
+}
+
+// NewTaskPoint creates a new task point. A POI without exactly two
+// coordinates gives a point that fails Task.Validate.
+func NewTaskPoint(poi POI, ignoreYaw bool) *TaskPoint {
+	pt := &TaskPoint{
+		AreaID:     poi.AreaID,
+		Type:       0,
+		StopRadius: 1,
+		Ext:        PointExt{Name: poi.Name},
+		StepActs:   []ActionType{},
+	}
+	if len(poi.Coordinate) == 2 {
+		pt.X, pt.Y = poi.Coordinate[0], poi.Coordinate[1]
+	} else {
+		pt.err = fmt.Errorf("POI %q has %d coordinates, want 2", poi.Name, len(poi.Coordinate))
+	}
+
+	if !ignoreYaw {
+		yaw := poi.Yaw
+		pt.Yaw = &yaw
+	}
+
+	return pt
+}
+
+// Name returns the name of the POI the point was built from, if known
+func (tp TaskPoint) Name() string {
+	return tp.Ext.Name
+}
+
+// AddStepActs adds a step action to the task point
+func (tp *TaskPoint) AddStepActs(stepAct ActionType) *TaskPoint {
+	tp.StepActs = append(tp.StepActs, stepAct)
+	return tp
+}
+
+// clone returns a copy of the point. Its yaw, step actions and their
+// Data maps are copied, including nested maps and slices decoded from
+// JSON; other values in Data, such as the userData of WaitAction, are
+// shared.
+func (tp *TaskPoint) clone() TaskPoint {
+	c := *tp
+	if tp.Yaw != nil {
+		yaw := *tp.Yaw
+		c.Yaw = &yaw
+	}
+	if tp.StepActs != nil {
+		c.StepActs = make([]ActionType, len(tp.StepActs))
+		for i, act := range tp.StepActs {
+			c.StepActs[i] = ActionType{Type: act.Type}
+			if act.Data != nil {
+				c.StepActs[i].Data = copyValue(act.Data).(map[string]interface{})
+			}
+		}
+	}
+	return c
+}
+
+// copyValue copies the maps and slices of a JSON-like value
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = copyValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = copyValue(e)
+		}
+		return l
+	}
+	return v
+}
+
+// Task is the payload of NewTask. It decodes from the JSON returned by
+// GetTaskInfo as well.
+type Task struct {
+	Name             string      `json:"name"`
+	RobotID          string      `json:"robotId"`
+	RouteMode        RouteMode   `json:"routeMode"`
+	RunMode          RunMode     `json:"runMode"`
+	RunNum           int         `json:"runNum"`
+	TaskType         TaskType    `json:"taskType"`
+	RunType          RunType     `json:"runType"`
+	SourceType       SourceType  `json:"sourceType"`
+	IgnorePublicSite bool        `json:"ignorePublicSite"`
+	Speed            float64     `json:"speed"`
+	TaskPts          []TaskPoint `json:"taskPts"`
+	BackPt           *TaskPoint  `json:"backPt,omitempty"`
+}
+
+// TaskBuilder helps build a task
+type TaskBuilder struct {
+	task Task
+	// pts and back are copied into task by GetTask, so points can still
+	// be changed after they are added
+	pts  []*TaskPoint
+	back *TaskPoint
+	errs []error
+}
+
+// BuilderOption sets a field of the task being built
+type BuilderOption func(*TaskBuilder)
+
+// WithTaskType sets the task type; the default is TaskTypeDelivery
+func WithTaskType(t TaskType) BuilderOption {
+	return func(tb *TaskBuilder) {
+		tb.task.TaskType = t
+	}
+}
+
+// WithRunType sets the run type; the default is RunTypeMultiPointDelivery
+func WithRunType(t RunType) BuilderOption {
+	return func(tb *TaskBuilder) {
+		tb.task.RunType = t
+	}
+}
+
+// WithRouteMode sets the route mode; the default is RouteSequential
+func WithRouteMode(m RouteMode) BuilderOption {
+	return func(tb *TaskBuilder) {
+		tb.task.RouteMode = m
+	}
+}
+
+// WithRunMode sets the run mode; the default is RunFlexible
+func WithRunMode(m RunMode) BuilderOption {
+	return func(tb *TaskBuilder) {
+		tb.task.RunMode = m
+	}
+}
+
+// WithSourceType sets the source type; the default is SourceOpenAPI
+func WithSourceType(t SourceType) BuilderOption {
+	return func(tb *TaskBuilder) {
+		tb.task.SourceType = t
+	}
+}
+
+// Limits bounds the speed and run count accepted by WithSpeed, WithRunNum
+// and Task.Validate. A zero MaxSpeed or MaxRunNum means no upper bound.
+type Limits struct {
+	// MinSpeed and MaxSpeed bound the speed in m/s
+	MinSpeed float64
+	MaxSpeed float64
+	// MaxRunNum is the most runs of a task that does not repeat forever
+	MaxRunNum int
+}
+
+// DefaultLimits are the limits checked by the builder and Validate. The
+// API documentation does not state any, so these values are assumptions;
+// change them to what your robots accept.
+var DefaultLimits = Limits{MinSpeed: 0.1, MaxSpeed: 2.0, MaxRunNum: 9999}
+
+// speedOK reports whether speed is within l; it must be positive in any case
+func (l Limits) speedOK(speed float64) bool {
+	return speed > 0 && speed >= l.MinSpeed && (l.MaxSpeed == 0 || speed <= l.MaxSpeed)
+}
+
+// runNumOK reports whether n runs are within l
+func (l Limits) runNumOK(n int) bool {
+	return n >= 1 && (l.MaxRunNum == 0 || n <= l.MaxRunNum)
+}
+
+// speedRange describes the speeds l accepts
+func (l Limits) speedRange() string {
+	if l.MaxSpeed == 0 {
+		return fmt.Sprintf("at least %v and positive", l.MinSpeed)
+	}
+	return fmt.Sprintf("within [%v, %v]", l.MinSpeed, l.MaxSpeed)
+}
+
+// runNumRange describes the run counts l accepts
+func (l Limits) runNumRange() string {
+	if l.MaxRunNum == 0 {
+		return "at least 1"
+	}
+	return fmt.Sprintf("within [1, %d]", l.MaxRunNum)
+}
+
+// RunForever as runNum repeats the task until it is cancelled. This is an
+// assumption: the API documentation does not say how a task repeats
+// indefinitely.
+const RunForever = 0
+
+// WithSpeed sets the speed in m/s, which must be within DefaultLimits;
+// the default is 1.0
+func WithSpeed(speed float64) BuilderOption {
+	return func(tb *TaskBuilder) {
+		if !DefaultLimits.speedOK(speed) {
+			tb.fail(fmt.Errorf("task: speed %v is not %s", speed, DefaultLimits.speedRange()))
+			return
+		}
+		tb.task.Speed = speed
+	}
+}
+
+// WithRunNum sets how many times the task runs, which must be within
+// DefaultLimits; the default is 1. Use WithRepeatForever for an endless
+// task.
+func WithRunNum(n int) BuilderOption {
+	return func(tb *TaskBuilder) {
+		if !DefaultLimits.runNumOK(n) {
+			tb.fail(fmt.Errorf("task: run count %d is not %s", n, DefaultLimits.runNumRange()))
+			return
+		}
+		tb.task.RunNum = n
+	}
+}
+
+// WithRepeatForever makes the task repeat until it is cancelled, e.g. for
+// a patrol, by sending RunForever as runNum
+func WithRepeatForever() BuilderOption {
+	return func(tb *TaskBuilder) {
+		tb.task.RunNum = RunForever
+	}
+}
+
+// WithIgnorePublicSite sets whether the robot may skip the public sites,
+// such as shared waiting points, on its route; the default is false
+func WithIgnorePublicSite(ignore bool) BuilderOption {
+	return func(tb *TaskBuilder) {
+		tb.task.IgnorePublicSite = ignore
+	}
+}
+
+// NewTaskBuilder creates a new task builder. Without options it builds a
+// multi-point delivery visiting the points in order.
+func NewTaskBuilder(name, robotId string, opts ...BuilderOption) *TaskBuilder {
+	tb := &TaskBuilder{
+		task: Task{
+			Name:             name,
+			RobotID:          robotId,
+			RouteMode:        RouteSequential,
+			RunMode:          RunFlexible,
+			RunNum:           1,
+			TaskType:         TaskTypeDelivery,
+			RunType:          RunTypeMultiPointDelivery,
+			SourceType:       SourceOpenAPI,
+			IgnorePublicSite: false,
+			Speed:            1.0,
+		},
+	}
+	return tb.Apply(opts...)
+}
+
+// Apply applies opts to the task being built. An invalid option leaves
+// its field unchanged and is reported by Err.
+func (tb *TaskBuilder) Apply(opts ...BuilderOption) *TaskBuilder {
+	for _, opt := range opts {
+		opt(tb)
+	}
+	return tb
+}
+
+// fail records an invalid option
+func (tb *TaskBuilder) fail(err error) {
+	tb.errs = append(tb.errs, err)
+}
+
+// Err returns the errors of all invalid options applied so far, or nil
+func (tb *TaskBuilder) Err() error {
+	return errors.Join(tb.errs...)
+}
+
+// Build returns the task, or the errors of the invalid options applied
+// together with the problems found by Task.Validate
+func (tb *TaskBuilder) Build() (*Task, error) {
+	task := tb.GetTask()
+	if err := errors.Join(tb.Err(), task.Validate()); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// AddTaskPt adds a task point to the task. The builder keeps tp, so step
+// actions added to it later are part of the task.
+func (tb *TaskBuilder) AddTaskPt(tp *TaskPoint) *TaskBuilder {
+	tb.pts = append(tb.pts, tp)
+	return tb
+}
+
+// SetBackPt sets the back point for the task. Like AddTaskPt, it keeps pt.
+func (tb *TaskBuilder) SetBackPt(pt *TaskPoint) *TaskBuilder {
+	tb.back = pt
+	return tb
+}
+
+// GetTask returns the complete task with copies of the points as they are
+// now; changing it does not affect the builder or the points
+func (tb *TaskBuilder) GetTask() *Task {
+	task := tb.task
+	task.TaskPts = make([]TaskPoint, len(tb.pts))
+	for i, pt := range tb.pts {
+		task.TaskPts[i] = pt.clone()
+	}
+	if tb.back != nil {
+		back := tb.back.clone()
+		task.BackPt = &back
+	}
+	return &task
+}
@@ -0,0 +1,388 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+	"github.com/AutoxingTech/APIDemo/go/axapi/auth"
+)
+
+func TestActionType_PauseAction(t *testing.T) {
+	type args struct {
+		duration int
+	}
+
+	tests := []struct {
+		name string
+		a    ActionType
+		args args
+		want ActionType
+	}{
+		// TODO: Add test cases.
+		{
+			name: "Test PauseAction with duration 10",
+			a:    ActionType{},
+			args: args{duration: 10},
+			want: ActionType{18, map[string]interface{}{"pauseTime": 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := ActionType{}
+			if got := a.PauseAction(tt.args.duration); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActionType.PauseAction() = %v, want %v", got, tt.want)
+
+			} else {
+				jsonData, err := json.Marshal(got)
+				if err != nil {
+					fmt.Println("Error marshaling JSON:", err)
+
+				}
+				t.Logf("JSON: %v", string(jsonData))
+			}
+		})
+	}
+}
+
+func TestActionType_LiftUp(t *testing.T) {
+	type fields struct {
+		Type int
+		Data map[string]interface{}
+	}
+	type args struct {
+		useAreaId *string
+	}
+
+	aid := "test"
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   ActionType
+	}{
+		// TODO: Add test cases.
+		{name: "test for liftup",
+
+			args: args{
+				useAreaId: &aid,
+			},
+			want: ActionType{
+				Type: 47,
+				Data: map[string]interface{}{
+					"useAreaId": aid,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := ActionType{}
+			if got := a.LiftUp(tt.args.useAreaId); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActionType.LiftUp() = %v, want %v", got, tt.want)
+			} else {
+				jsonData, err := json.Marshal(got)
+				if err != nil {
+					fmt.Println("Error marshaling JSON:", err)
+				}
+				fmt.Println("JSON:", string(jsonData))
+			}
+		})
+	}
+}
+
+func TestActionType_LiftDown(t *testing.T) {
+	type fields struct {
+		Type int
+		Data map[string]interface{}
+	}
+	type args struct {
+		useAreaId *string
+	}
+
+	aid := "test"
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   ActionType
+	}{
+		// TODO: Add test cases.
+		{name: "test for liftdown",
+
+			args: args{
+				useAreaId: &aid,
+			},
+			want: ActionType{
+				Type: 48,
+				Data: map[string]interface{}{
+					"useAreaId": aid,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := ActionType{
+				Type: tt.fields.Type,
+				Data: tt.fields.Data,
+			}
+			if got := a.LiftDown(tt.args.useAreaId); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActionType.LiftDown() = %v, want %v", got, tt.want)
+			} else {
+				jsonData, err := json.Marshal(got)
+				if err != nil {
+					fmt.Println("Error marshaling JSON:", err)
+				}
+				fmt.Println("JSON:", string(jsonData))
+			}
+		})
+
+	}
+}
+
+func TestAxTask(t *testing.T) {
+
+	config, err := axapi.LoadConfig("")
+	if err != nil {
+		t.Skip("API credentials not configured:", err)
+	}
+
+	client := axapi.NewClient(config)
+	tokenManager := auth.NewTokenManager(client)
+	_, err = tokenManager.GetToken(context.Background())
+	if err != nil {
+		t.Error("Failed to get token:", err)
+		return
+	}
+	// Example usage
+	poi1 := POI{
+		AreaID:     "66ea87fe6cb0037e92ba0ac4",
+		Coordinate: []float64{-0.22222543918815063, 1.6403502840489637},
+		Name:       "m1",
+		Yaw:        0,
+	}
+
+	poi2 := POI{
+		AreaID:     "66ea87fe6cb0037e92ba0ac4",
+		Coordinate: []float64{-0.16790582975545476, 3.853874768537935},
+		Name:       "m2",
+		Yaw:        0,
+	}
+
+	task := NewTaskBuilder("Task1", config.RobotID)
+
+	tp1 := NewTaskPoint(poi1, true)
+	task.AddTaskPt(tp1)
+
+	// # 添加动作 "3111002" "3111012" 为音频ID 音频列表可以联系技术支持
+	// # Add action "3111002" "3111012" as audio ID. Audio list can contact technical support.
+	// # 添加动作 "PauseAction(10)" 为暂停10秒
+	// # Add action "PauseAction(10)" to pause for 10 seconds
+	tp2 := NewTaskPoint(poi2, true)
+	tp2.AddStepActs(Action.PlayAudioAction("3111002")).
+		AddStepActs(Action.PauseAction(10)).
+		AddStepActs(Action.PlayAudioAction("3111012"))
+	task.AddTaskPt(tp2)
+
+	// # 添加动作 "test" 为自定义数据，当任务到达该点时，会触发事件，可以在websocket接口中获取触发事件。
+	// # Add action "test" as custom data. When the task reaches this point, an event will be triggered. The trigger event can be obtained in the websocket interface.
+	park := NewTaskPoint(poi1, true)
+	park.AddStepActs(Action.WaitAction(map[string]string{"cmd": "test"}))
+	task.SetBackPt(park)
+
+	fmt.Printf("Task: %+v\n", task.GetTask())
+
+	manager := NewTaskManager(client.WithTokenSource(tokenManager))
+
+	taskID, err := manager.NewTask(context.Background(), task.GetTask())
+	fmt.Printf("New task created: %v, ID: %s\n", err, taskID)
+
+	if err == nil {
+		err = manager.ExecuteTask(context.Background(), taskID)
+		if err == nil {
+			t.Log("TestAxToken passed")
+			return
+		}
+	}
+
+	t.Error("TestAxToken failed:", err)
+}
+
+func TestAxTaskBuild(t *testing.T) {
+
+	// Example usage
+	poi1 := POI{
+		AreaID:     "66ea87fe6cb0037e92ba0ac4",
+		Coordinate: []float64{-0.22222543918815063, 1.6403502840489637},
+		Name:       "m1",
+		Yaw:        0,
+	}
+
+	poi2 := POI{
+		AreaID:     "66ea87fe6cb0037e92ba0ac4",
+		Coordinate: []float64{-0.16790582975545476, 3.853874768537935},
+		Name:       "m2",
+		Yaw:        0,
+	}
+
+	task := NewTaskBuilder("Task1", "RobotID")
+
+	tp1 := NewTaskPoint(poi1, true)
+	task.AddTaskPt(tp1)
+
+	// # 添加动作 "3111002" "3111012" 为音频ID 音频列表可以联系技术支持
+	// # Add action "3111002" "3111012" as audio ID. Audio list can contact technical support.
+	// # 添加动作 "PauseAction(10)" 为暂停10秒
+	// # Add action "PauseAction(10)" to pause for 10 seconds
+	// # Add action "LiftUp(&aid)"
+	// # Add action "LiftDown(&aid)"
+
+	tp2 := NewTaskPoint(poi2, true)
+	aid := "aid_xxxxxxxx"
+	tp2.AddStepActs(Action.PlayAudioAction("3111002")).
+		AddStepActs(Action.LiftUp(&aid)).
+		AddStepActs(Action.PauseAction(10)).
+		AddStepActs(Action.LiftDown(&aid)).
+		AddStepActs(Action.PlayAudioAction("3111012"))
+	task.AddTaskPt(tp2)
+
+	// # 添加动作 "test" 为自定义数据，当任务到达该点时，会触发事件，可以在websocket接口中获取触发事件。
+	// # Add action "test" as custom data. When the task reaches this point, an event will be triggered. The trigger event can be obtained in the websocket interface.
+	park := NewTaskPoint(poi1, true)
+	park.AddStepActs(Action.WaitAction(map[string]string{"cmd": "test"}))
+	task.SetBackPt(park)
+
+	fmt.Printf("Task: %+v\n", task.GetTask())
+
+	jsonData, err := json.Marshal(task.GetTask())
+	if err != nil {
+		fmt.Println("Error marshaling JSON:", err)
+	}
+	fmt.Println("JSON:", string(jsonData))
+
+}
+
+func TestTask_JSONRoundTrip(t *testing.T) {
+	poi := POI{AreaID: "a1", Coordinate: []float64{1.5, -2}, Name: "m1", Yaw: 90}
+	b := NewTaskBuilder("Task1", "r1")
+	b.AddTaskPt(NewTaskPoint(poi, false).AddStepActs(Action.PauseAction(10)))
+	b.AddTaskPt(NewTaskPoint(poi, true))
+	b.SetBackPt(NewTaskPoint(poi, true))
+
+	data, err := json.Marshal(b.GetTask())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	// the payload decodes as the task part of a GetTaskInfo response
+	var info TaskInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if info.Extra != nil {
+		t.Errorf("unmodeled fields = %v", info.Extra)
+	}
+	again, err := json.Marshal(info.Task)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("round trip =\n%s\nwant\n%s", again, data)
+	}
+	if pt := info.TaskPts[0]; pt.Yaw == nil || *pt.Yaw != 90 || pt.Name() != "m1" {
+		t.Errorf("first point = %+v", pt)
+	}
+	if info.TaskPts[1].Yaw != nil {
+		t.Errorf("second point yaw = %v, want none", *info.TaskPts[1].Yaw)
+	}
+}
+
+func TestTaskBuilder_RunOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []BuilderOption
+		speed   float64
+		runNum  int
+		ignore  bool
+		wantErr bool
+	}{
+		{name: "defaults", speed: 1, runNum: 1},
+		{name: "slow loops", opts: []BuilderOption{WithSpeed(0.5), WithRunNum(3), WithIgnorePublicSite(true)}, speed: 0.5, runNum: 3, ignore: true},
+		{name: "forever", opts: []BuilderOption{WithRepeatForever()}, speed: 1, runNum: RunForever},
+		{name: "too fast", opts: []BuilderOption{WithSpeed(3)}, speed: 1, runNum: 1, wantErr: true},
+		{name: "zero runs", opts: []BuilderOption{WithRunNum(0)}, speed: 1, runNum: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewTaskBuilder("t", "r1", tt.opts...)
+			b.AddTaskPt(NewTaskPoint(POI{AreaID: "a1", Coordinate: []float64{0, 0}}, true))
+			task := b.GetTask()
+			if task.Speed != tt.speed || task.RunNum != tt.runNum || task.IgnorePublicSite != tt.ignore {
+				t.Errorf("task = speed %v, runNum %d, ignore %v, want %v, %d, %v",
+					task.Speed, task.RunNum, task.IgnorePublicSite, tt.speed, tt.runNum, tt.ignore)
+			}
+			if _, err := b.Build(); (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// the limits are assumptions and can be raised
+	saved := DefaultLimits
+	defer func() { DefaultLimits = saved }()
+	DefaultLimits.MaxSpeed = 3
+	DefaultLimits.MaxRunNum = 0
+	b := NewTaskBuilder("t", "r1", WithSpeed(2.5), WithRunNum(100000))
+	b.AddTaskPt(NewTaskPoint(POI{AreaID: "a1", Coordinate: []float64{0, 0}}, true))
+	if task, err := b.Build(); err != nil || task.Speed != 2.5 || task.RunNum != 100000 {
+		t.Errorf("Build() with raised limits = %+v, %v", task, err)
+	}
+	DefaultLimits = saved
+
+	b = NewTaskBuilder("t", "r1", WithSpeed(0), WithRunNum(-1))
+	if err := b.Err(); err == nil || !strings.Contains(err.Error(), "speed") || !strings.Contains(err.Error(), "run count") {
+		t.Errorf("Err() = %v, want both problems", err)
+	}
+}
+
+func TestTaskBuilder_PointsAddedEarly(t *testing.T) {
+	poi := POI{AreaID: "a1", Coordinate: []float64{1, 2}}
+	b := NewTaskBuilder("t", "r1")
+	pt := NewTaskPoint(poi, true)
+	back := NewTaskPoint(poi, true)
+	b.AddTaskPt(pt).SetBackPt(back)
+
+	// actions added after the points are part of the task
+	pt.AddStepActs(Action.PauseAction(10))
+	back.AddStepActs(Action.WaitAction("done"))
+	task, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(task.TaskPts[0].StepActs) != 1 || len(task.BackPt.StepActs) != 1 {
+		t.Fatalf("task = %+v, want the late actions", task)
+	}
+
+	// the task does not share its actions with the points
+	task.TaskPts[0].StepActs[0] = Action.PauseAction(99)
+	pt.AddStepActs(Action.PauseAction(20))
+	if got := pt.StepActs[0].Data["pauseTime"]; got != 10 {
+		t.Errorf("point pauseTime = %v, want 10", got)
+	}
+	if len(task.TaskPts[0].StepActs) != 1 {
+		t.Errorf("task point has %d actions, want 1", len(task.TaskPts[0].StepActs))
+	}
+
+	// nor their data
+	task.BackPt.StepActs[0].Data["userData"] = "changed"
+	if got := back.StepActs[0].Data["userData"]; got != "done" {
+		t.Errorf("back point userData = %v, want done", got)
+	}
+}
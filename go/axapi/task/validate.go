@@ -0,0 +1,168 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrInvalidTask matches the *ValidationError returned by Task.Validate
+var ErrInvalidTask = errors.New("task: invalid task")
+
+// Limits of the stop radius of a task point, in meters
+const (
+	MinStopRadius = 0.1
+	MaxStopRadius = 5.0
+)
+
+// Problem is one thing wrong with a task
+type Problem struct {
+	// Field is the JSON path of the offending field, e.g. "taskPts[1].x"
+	Field   string
+	Message string
+}
+
+// String formats the problem as "field: message"
+func (p Problem) String() string {
+	return p.Field + ": " + p.Message
+}
+
+// ValidationError lists every problem Validate found
+type ValidationError struct {
+	Problems []Problem
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.String()
+	}
+	return "task: invalid task: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether target is ErrInvalidTask
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidTask
+}
+
+// validator collects problems
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) addf(field, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{field, fmt.Sprintf(format, args...)})
+}
+
+// Validate checks the task before it is sent and returns a *ValidationError
+// listing all problems found, or nil. Speed and runNum are checked against
+// DefaultLimits.
+func (t *Task) Validate() error {
+	var v validator
+
+	if t.Name == "" {
+		v.addf("name", "is required")
+	}
+	if t.RobotID == "" {
+		v.addf("robotId", "is required")
+	}
+	if _, ok := taskTypeNames[t.TaskType]; !ok {
+		v.addf("taskType", "unknown value %d", t.TaskType)
+	}
+	if _, ok := runTypeNames[t.RunType]; !ok {
+		v.addf("runType", "unknown value %d", t.RunType)
+	}
+	if _, ok := routeModeNames[t.RouteMode]; !ok {
+		v.addf("routeMode", "unknown value %d", t.RouteMode)
+	}
+	if _, ok := runModeNames[t.RunMode]; !ok {
+		v.addf("runMode", "unknown value %d", t.RunMode)
+	}
+	if _, ok := sourceTypeNames[t.SourceType]; !ok {
+		v.addf("sourceType", "unknown value %d", t.SourceType)
+	}
+	if t.RunNum != RunForever && !DefaultLimits.runNumOK(t.RunNum) {
+		v.addf("runNum", "%d is not %s or %d to repeat forever", t.RunNum, DefaultLimits.runNumRange(), RunForever)
+	}
+	if !DefaultLimits.speedOK(t.Speed) {
+		v.addf("speed", "%v is not %s", t.Speed, DefaultLimits.speedRange())
+	}
+
+	if len(t.TaskPts) == 0 {
+		v.addf("taskPts", "at least one task point is required")
+	}
+	for i, pt := range t.TaskPts {
+		v.point(fmt.Sprintf("taskPts[%d]", i), pt)
+	}
+	if t.BackPt != nil {
+		v.point("backPt", *t.BackPt)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// point checks a task point
+func (v *validator) point(field string, pt TaskPoint) {
+	if pt.err != nil {
+		v.addf(field, "%v", pt.err)
+	}
+	if pt.AreaID == "" {
+		v.addf(field+".areaId", "is required")
+	}
+	if !finite(pt.X) || !finite(pt.Y) {
+		v.addf(field, "coordinates (%v, %v) are not finite", pt.X, pt.Y)
+	}
+	if pt.Yaw != nil && !finite(*pt.Yaw) {
+		v.addf(field+".yaw", "%v is not finite", *pt.Yaw)
+	}
+	if pt.StopRadius < MinStopRadius || pt.StopRadius > MaxStopRadius {
+		v.addf(field+".stopRadius", "%v is outside [%v, %v]", pt.StopRadius, MinStopRadius, MaxStopRadius)
+	}
+	for i, act := range pt.StepActs {
+		v.action(fmt.Sprintf("%s.stepActs[%d]", field, i), act)
+	}
+}
+
+// action checks the data required by the step action types this package
+// builds. Other types are passed through, as the server may know them.
+func (v *validator) action(field string, act ActionType) {
+	data := field + ".data"
+	switch act.Type {
+	case ActionPause:
+		if n, ok := number(act.Data["pauseTime"]); !ok || n < 0 {
+			v.addf(data+".pauseTime", "must be a number of seconds >= 0")
+		}
+	case ActionPlayAudio:
+		id, _ := act.Data["audioId"].(string)
+		url, _ := act.Data["url"].(string)
+		if id == "" && url == "" {
+			v.addf(data, "audioId or url is required")
+		}
+	case ActionWait:
+		if act.Data["userData"] == nil {
+			v.addf(data+".userData", "is required")
+		}
+	}
+}
+
+// number returns v as a float64 if it is a number
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
@@ -0,0 +1,147 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// validTask returns a task that passes Validate
+func validTask() *Task {
+	aid := "a1"
+	b := NewTaskBuilder("demo", "r1")
+	b.AddTaskPt(NewTaskPoint(POI{AreaID: "a1", Coordinate: []float64{1, 2}, Name: "m1"}, false).
+		AddStepActs(Action.PlayAudioAction("3111002")).
+		AddStepActs(Action.PauseAction(10)).
+		AddStepActs(Action.LiftUp(&aid)))
+	b.SetBackPt(NewTaskPoint(POI{AreaID: "a1", Coordinate: []float64{0, 0}}, true).
+		AddStepActs(Action.WaitAction(map[string]string{"cmd": "test"})))
+	return b.GetTask()
+}
+
+func problemFields(err error) []string {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return nil
+	}
+	var fields []string
+	for _, p := range verr.Problems {
+		fields = append(fields, p.Field)
+	}
+	return fields
+}
+
+func TestTask_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(task *Task)
+		want   []string
+	}{
+		{name: "valid", change: func(task *Task) {}},
+		{name: "repeat forever", change: func(task *Task) { task.RunNum = RunForever }},
+		{
+			name: "missing fields",
+			change: func(task *Task) {
+				task.Name = ""
+				task.RobotID = ""
+				task.TaskPts = nil
+			},
+			want: []string{"name", "robotId", "taskPts"},
+		},
+		{
+			name: "out of range",
+			change: func(task *Task) {
+				task.Speed = 0
+				task.RunNum = -1
+				task.RunType = 99
+				task.TaskPts[0].StopRadius = 10
+			},
+			want: []string{"runType", "runNum", "speed", "taskPts[0].stopRadius"},
+		},
+		{
+			name: "short coordinate",
+			change: func(task *Task) {
+				task.TaskPts = append(task.TaskPts, *NewTaskPoint(POI{AreaID: "a1", Coordinate: []float64{1}}, true))
+			},
+			want: []string{"taskPts[1]"},
+		},
+		{
+			name: "action without builder",
+			change: func(task *Task) {
+				task.TaskPts[0].StepActs = append(task.TaskPts[0].StepActs, ActionType{Type: 99, Data: map[string]interface{}{"any": 1}})
+			},
+		},
+		{
+			name: "bad point",
+			change: func(task *Task) {
+				task.BackPt.AreaID = ""
+				task.BackPt.X = math.NaN()
+			},
+			want: []string{"backPt.areaId", "backPt"},
+		},
+		{
+			name: "bad actions",
+			change: func(task *Task) {
+				task.TaskPts[0].StepActs = []ActionType{
+					{Type: ActionPause, Data: map[string]interface{}{"pauseTime": "10"}},
+					{Type: ActionPlayAudio, Data: map[string]interface{}{"audioId": ""}},
+					{Type: ActionWait, Data: map[string]interface{}{}},
+				}
+			},
+			want: []string{
+				"taskPts[0].stepActs[0].data.pauseTime",
+				"taskPts[0].stepActs[1].data",
+				"taskPts[0].stepActs[2].data.userData",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := validTask()
+			tt.change(task)
+			err := task.Validate()
+			if (err != nil) != (tt.want != nil) {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTask) {
+				t.Errorf("Validate() error = %v, want ErrInvalidTask", err)
+			}
+			if got := problemFields(err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() problems = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskManager_NewTaskInvalid(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status":200,"data":{"taskId":"t1"}}`))
+	}))
+	defer srv.Close()
+
+	manager := NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	if _, err := manager.NewTask(context.Background(), NewTaskBuilder("empty", "r1").GetTask()); !errors.Is(err, ErrInvalidTask) {
+		t.Errorf("NewTask() error = %v, want ErrInvalidTask", err)
+	}
+	if calls != 0 {
+		t.Errorf("NewTask() sent %d requests for an invalid task", calls)
+	}
+	if id, err := manager.NewTask(context.Background(), validTask()); err != nil || id != "t1" {
+		t.Errorf("NewTask() = %q, %v", id, err)
+	}
+
+	// the caller can skip the check
+	unknown := validTask()
+	unknown.RunType = 99
+	if id, err := manager.NewTaskUnchecked(context.Background(), unknown); err != nil || id != "t1" || calls != 2 {
+		t.Errorf("NewTaskUnchecked() = %q, %v after %d requests", id, err, calls)
+	}
+}
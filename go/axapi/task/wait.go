@@ -0,0 +1,168 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrWaitTimeout is returned by WaitForTask when the task is still running
+// at the deadline
+var ErrWaitTimeout = errors.New("task: timed out waiting for task")
+
+// Defaults used by WaitForTask for zero WaitOptions fields
+const (
+	DefaultWaitInterval    = time.Second
+	DefaultMaxWaitInterval = 10 * time.Second
+)
+
+// WaitOptions configures WaitForTask
+type WaitOptions struct {
+	// Interval is the first wait between polls; it doubles after every poll
+	// without progress, up to MaxInterval. Defaults to DefaultWaitInterval.
+	Interval time.Duration
+	// MaxInterval defaults to DefaultMaxWaitInterval
+	MaxInterval time.Duration
+	// Timeout bounds the whole wait in addition to the deadline of ctx;
+	// zero means no extra bound
+	Timeout time.Duration
+	// OnProgress is called once for every task point reached, in order,
+	// with its index in TaskPts. The back point is not reported. For a
+	// task that runs several times, CurrentIndex going back down starts a
+	// new lap whose points are reported again; a lap that passes entirely
+	// between two updates goes unnoticed.
+	OnProgress func(info TaskInfo, index int)
+	// Push optionally delivers task updates pushed by the server, e.g.
+	// converted from ws.TaskEvent. Their flags and CurrentIndex are merged
+	// into the last polled info, so MaxInterval can be raised when Push is
+	// used.
+	Push <-chan TaskInfo
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	var w WaitOptions
+	if o != nil {
+		w = *o
+	}
+	if w.Interval <= 0 {
+		w.Interval = DefaultWaitInterval
+	}
+	if w.MaxInterval < w.Interval {
+		w.MaxInterval = max(DefaultMaxWaitInterval, w.Interval)
+	}
+	return w
+}
+
+// WaitForTask waits until the task is finished, cancelled or failed and
+// returns its final info; check info.Status() for the outcome. If the
+// deadline of ctx or opts.Timeout passes first, it returns the last info
+// seen with an error matching ErrWaitTimeout.
+func (tm *TaskManager) WaitForTask(ctx context.Context, taskId string, opts *WaitOptions) (TaskInfo, error) {
+	o := opts.withDefaults()
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	var last TaskInfo
+	reached, total, index := 0, 0, 0
+	// progress reports the points reached before upto
+	progress := func(info TaskInfo, upto int) {
+		for ; reached < upto; reached++ {
+			if o.OnProgress != nil {
+				o.OnProgress(info, reached)
+			}
+		}
+	}
+	// observe records info and reports whether the task is done
+	observe := func(info TaskInfo) bool {
+		if len(info.TaskPts) > 0 {
+			total = len(info.TaskPts)
+		}
+		if info.CurrentIndex < index && !info.Status().Done() {
+			// the next lap of a repeating task; the last one is complete
+			progress(info, total)
+			reached = 0
+		}
+		index = info.CurrentIndex
+		upto := info.CurrentIndex
+		if info.Status() == StatusFinished {
+			upto = total
+		}
+		progress(info, upto)
+		last = info
+		return info.Status().Done()
+	}
+
+	wait := o.Interval
+	poll := true
+	for {
+		if poll {
+			before := reached
+			info, err := tm.GetTaskInfo(ctx, taskId)
+			if err != nil {
+				return last, waitError(ctx, err)
+			}
+			if observe(info) {
+				return info, nil
+			}
+			if reached > before {
+				wait = o.Interval
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, waitError(ctx, ctx.Err())
+		case <-timer.C:
+			poll = true
+			wait = min(2*wait, o.MaxInterval)
+		case info, ok := <-o.Push:
+			timer.Stop()
+			poll = false
+			if !ok {
+				o.Push = nil
+				poll = true
+				continue
+			}
+			if info.TaskID != "" && info.TaskID != taskId {
+				continue
+			}
+			if info := mergePush(last, info); observe(info) {
+				return info, nil
+			}
+		}
+	}
+}
+
+// mergePush applies the state carried by a pushed update, which lacks the
+// task definition, to the last full info
+func mergePush(last, push TaskInfo) TaskInfo {
+	info := last
+	if push.TaskID != "" {
+		info.TaskID = push.TaskID
+	}
+	if push.RobotID != "" {
+		info.RobotID = push.RobotID
+	}
+	info.IsExcute = push.IsExcute
+	info.IsFinish = push.IsFinish
+	info.IsCancel = push.IsCancel
+	info.CurrentIndex = push.CurrentIndex
+	if push.ErrorCode != 0 || push.ErrorMsg != "" {
+		info.ErrorCode, info.ErrorMsg = push.ErrorCode, push.ErrorMsg
+	}
+	return info
+}
+
+// waitError marks err as a timeout if the deadline of ctx has passed
+func waitError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrWaitTimeout, err)
+	}
+	return err
+}
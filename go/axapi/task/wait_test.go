@@ -0,0 +1,143 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// progressServer serves GetTaskInfo for a three point task, advancing one
+// step per call through the given states
+func progressServer(states []string) *httptest.Server {
+	var mu sync.Mutex
+	calls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		state := states[min(calls, len(states)-1)]
+		calls++
+		mu.Unlock()
+		fmt.Fprintf(w, `{"status":200,"data":{"taskId":"t1","taskPts":[{},{},{}],%s}}`, state)
+	}))
+}
+
+func TestTaskManager_WaitForTask(t *testing.T) {
+	tests := []struct {
+		name        string
+		states      []string
+		want        Status
+		wantReached []int
+	}{
+		{
+			name: "finished",
+			states: []string{
+				`"isExcute":false`,
+				`"isExcute":true,"curPtIndex":0`,
+				`"isExcute":true,"curPtIndex":2`,
+				`"isExcute":true,"isFinish":true,"curPtIndex":2`,
+			},
+			want:        StatusFinished,
+			wantReached: []int{0, 1, 2},
+		},
+		{
+			name: "repeating",
+			states: []string{
+				`"isExcute":true,"curPtIndex":1`,
+				`"isExcute":true,"curPtIndex":2`,
+				`"isExcute":true,"curPtIndex":0`,
+				`"isExcute":true,"curPtIndex":1`,
+				`"isExcute":true,"isFinish":true,"curPtIndex":0`,
+			},
+			want:        StatusFinished,
+			wantReached: []int{0, 1, 2, 0, 1, 2},
+		},
+		{
+			name: "cancelled",
+			states: []string{
+				`"isExcute":true,"curPtIndex":1`,
+				`"isExcute":true,"isCancel":true,"curPtIndex":1`,
+			},
+			want:        StatusCancelled,
+			wantReached: []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := progressServer(tt.states)
+			defer srv.Close()
+
+			manager := NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+			var reached []int
+			info, err := manager.WaitForTask(context.Background(), "t1", &WaitOptions{
+				Interval: time.Millisecond,
+				OnProgress: func(info TaskInfo, index int) {
+					reached = append(reached, index)
+				},
+			})
+			if err != nil {
+				t.Fatalf("WaitForTask() error = %v", err)
+			}
+			if info.Status() != tt.want {
+				t.Errorf("WaitForTask() status = %v, want %v", info.Status(), tt.want)
+			}
+			if !reflect.DeepEqual(reached, tt.wantReached) {
+				t.Errorf("reached %v, want %v", reached, tt.wantReached)
+			}
+		})
+	}
+}
+
+func TestTaskManager_WaitForTaskTimeout(t *testing.T) {
+	srv := progressServer([]string{`"isExcute":true`})
+	defer srv.Close()
+
+	manager := NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	info, err := manager.WaitForTask(context.Background(), "t1", &WaitOptions{
+		Interval: time.Millisecond,
+		Timeout:  30 * time.Millisecond,
+	})
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Errorf("WaitForTask() error = %v, want ErrWaitTimeout", err)
+	}
+	if info.TaskID != "t1" || info.Status() != StatusExecuting {
+		t.Errorf("WaitForTask() = %+v, want the last info seen", info)
+	}
+}
+
+func TestTaskManager_WaitForTaskPush(t *testing.T) {
+	srv := progressServer([]string{`"isExcute":true`})
+	defer srv.Close()
+
+	push := make(chan TaskInfo, 2)
+	push <- TaskInfo{TaskID: "other", IsCancel: true}
+	push <- TaskInfo{TaskID: "t1", IsExcute: true, IsFinish: true}
+
+	manager := NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}))
+	var reached []int
+	info, err := manager.WaitForTask(context.Background(), "t1", &WaitOptions{
+		Interval:   time.Hour,
+		Push:       push,
+		OnProgress: func(info TaskInfo, index int) { reached = append(reached, index) },
+	})
+	if err != nil {
+		t.Fatalf("WaitForTask() error = %v", err)
+	}
+	if info.Status() != StatusFinished {
+		t.Errorf("WaitForTask() status = %v, want finished", info.Status())
+	}
+	// the pushed flags are merged into the polled info
+	if info.TaskID != "t1" || len(info.TaskPts) != 3 {
+		t.Errorf("WaitForTask() = %+v, want the polled task with 3 points", info)
+	}
+	// the point count comes from the polled info
+	if !reflect.DeepEqual(reached, []int{0, 1, 2}) {
+		t.Errorf("reached %v, want [0 1 2]", reached)
+	}
+}
@@ -0,0 +1,47 @@
+package axapi
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Time is a timestamp sent by the API as Unix milliseconds
+type Time struct {
+	time.Time
+}
+
+// UnmarshalJSON accepts a number or numeric string of Unix milliseconds.
+// Zero and null leave the time unset.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = Time{}
+		return nil
+	}
+	var ms json.Number
+	if err := json.Unmarshal(data, &ms); err != nil {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		ms = json.Number(s)
+	}
+	n, err := strconv.ParseFloat(string(ms), 64)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		*t = Time{}
+		return nil
+	}
+	*t = Time{time.UnixMilli(int64(n))}
+	return nil
+}
+
+// MarshalJSON encodes the time as Unix milliseconds, or 0 when unset
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+	return []byte(strconv.FormatInt(t.UnixMilli(), 10)), nil
+}
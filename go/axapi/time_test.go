@@ -0,0 +1,35 @@
+package axapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTime_JSON(t *testing.T) {
+	tests := []struct {
+		in     string
+		millis int64
+		zero   bool
+		out    string
+	}{
+		{in: `1700000000123`, millis: 1700000000123, out: `1700000000123`},
+		{in: `"1700000000123"`, millis: 1700000000123, out: `1700000000123`},
+		{in: `0`, zero: true, out: `0`},
+		{in: `null`, zero: true, out: `0`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var tm Time
+			if err := json.Unmarshal([]byte(tt.in), &tm); err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", tt.in, err)
+			}
+			if tm.IsZero() != tt.zero || (!tt.zero && tm.UnixMilli() != tt.millis) {
+				t.Errorf("Unmarshal(%s) = %v", tt.in, tm)
+			}
+			out, _ := json.Marshal(tm)
+			if string(out) != tt.out {
+				t.Errorf("Marshal() = %s, want %s", out, tt.out)
+			}
+		})
+	}
+}
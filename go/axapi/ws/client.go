@@ -0,0 +1,354 @@
+// Package ws receives real-time robot and task events over the WebSocket
+// interface.
+//
+// The API documentation only says that real-time status is available
+// through the WebSocket interface; it does not describe the protocol. The
+// defaults of this package are therefore assumptions: the path DefaultPath,
+// subscription messages of the form
+//
+//	{"op":"subscribe","topics":["robot/<robotId>/state"]}
+//
+// the topic names of RobotTopic, RobotTasksTopic and TaskTopic, and
+// frames of the form
+//
+//	{"topic":"...","type":"robotState","timestamp":1700000000000,"data":{...}}
+//
+// Use WithURL and WithSubscriptionFunc, and topics of your own, to adapt the
+// client to the actual interface.
+//
+// The client connects with the X-Token header of the API client and
+// decodes the frames it receives into typed events. Lost connections are
+// re-established with backoff and the topics are subscribed again.
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+// DefaultPath is appended to the API base URL when no URL is given. It is
+// an assumption; see the package documentation.
+const DefaultPath = "/ws/v1.1"
+
+// DefaultPingInterval is how often the client pings the server; a
+// connection that stays silent for twice as long is considered lost
+const DefaultPingInterval = 30 * time.Second
+
+// DefaultReconnectPolicy is the backoff between connection attempts.
+// MaxAttempts is zero, so the client never gives up.
+var DefaultReconnectPolicy = axapi.RetryPolicy{
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// Client is a WebSocket connection to the event interface that survives
+// reconnects
+type Client struct {
+	url          string
+	tokens       axapi.TokenSource
+	dialer       *websocket.Dialer
+	reconnect    axapi.RetryPolicy
+	pingInterval time.Duration
+	subscription SubscriptionFunc
+
+	// mu guards topics and conn, and serializes writes to conn
+	mu     sync.Mutex
+	topics map[string]bool
+	conn   *websocket.Conn
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithURL sets the WebSocket URL, e.g. "wss://api.autoxing.com/ws/v1.1"
+func WithURL(u string) Option {
+	return func(c *Client) {
+		c.url = u
+	}
+}
+
+// SubscriptionFunc builds the control message for op, OpSubscribe or
+// OpUnsubscribe, and topics. The result is sent as JSON.
+type SubscriptionFunc func(op string, topics []string) interface{}
+
+// Subscription operations passed to a SubscriptionFunc
+const (
+	OpSubscribe   = "subscribe"
+	OpUnsubscribe = "unsubscribe"
+)
+
+// DefaultSubscription builds the assumed {"op":...,"topics":[...]} message
+func DefaultSubscription(op string, topics []string) interface{} {
+	return subscription{Op: op, Topics: topics}
+}
+
+// WithSubscriptionFunc replaces DefaultSubscription as the format of the
+// subscription messages
+func WithSubscriptionFunc(f SubscriptionFunc) Option {
+	return func(c *Client) {
+		c.subscription = f
+	}
+}
+
+// WithDialer replaces the default websocket.Dialer
+func WithDialer(d *websocket.Dialer) Option {
+	return func(c *Client) {
+		c.dialer = d
+	}
+}
+
+// WithReconnectPolicy sets the backoff between connection attempts.
+// A positive MaxAttempts makes Run give up after that many consecutive
+// failures.
+func WithReconnectPolicy(p axapi.RetryPolicy) Option {
+	return func(c *Client) {
+		c.reconnect = p
+	}
+}
+
+// WithPingInterval sets how often the server is pinged
+func WithPingInterval(d time.Duration) Option {
+	return func(c *Client) {
+		c.pingInterval = d
+	}
+}
+
+// NewClient creates an event client that authenticates with the token
+// source of api. Its URL is derived from the base URL of api unless
+// WithURL is given.
+func NewClient(api *axapi.Client, opts ...Option) *Client {
+	c := &Client{
+		url:          wsURL(api.BaseURL()),
+		tokens:       api.TokenSource(),
+		dialer:       websocket.DefaultDialer,
+		reconnect:    DefaultReconnectPolicy,
+		pingInterval: DefaultPingInterval,
+		subscription: DefaultSubscription,
+		topics:       map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// wsURL turns an http(s) base URL into the ws(s) URL of the event interface
+func wsURL(base string) string {
+	switch {
+	case strings.HasPrefix(base, "https://"):
+		base = "wss://" + strings.TrimPrefix(base, "https://")
+	case strings.HasPrefix(base, "http://"):
+		base = "ws://" + strings.TrimPrefix(base, "http://")
+	}
+	return base + DefaultPath
+}
+
+// subscription is the control message built by DefaultSubscription
+type subscription struct {
+	Op     string   `json:"op"`
+	Topics []string `json:"topics"`
+}
+
+// Subscribe adds topics to the subscriptions. They are sent right away if
+// connected and again after every reconnect, so an error here only means
+// the current connection did not take them.
+func (c *Client) Subscribe(topics ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, t := range topics {
+		c.topics[t] = true
+	}
+	return c.writeLocked(c.subscription(OpSubscribe, topics))
+}
+
+// Unsubscribe removes topics from the subscriptions
+func (c *Client) Unsubscribe(topics ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, t := range topics {
+		delete(c.topics, t)
+	}
+	return c.writeLocked(c.subscription(OpUnsubscribe, topics))
+}
+
+// writeLocked sends msg if connected; c.mu must be held
+func (c *Client) writeLocked(msg interface{}) error {
+	if c.conn == nil {
+		return nil
+	}
+	if err := c.conn.WriteJSON(msg); err != nil {
+		return fmt.Errorf("sending %T: %w", msg, err)
+	}
+	return nil
+}
+
+// Run connects and calls handle for every event, in order, until ctx is
+// done. The connection is re-established with backoff whenever it is lost;
+// handle is told through ConnectedEvent and DisconnectedEvent. Run returns
+// ctx.Err(), or the last error once the reconnect policy gives up.
+// handle runs on the read loop and should not block for long.
+func (c *Client) Run(ctx context.Context, handle func(Event)) error {
+	failures := 0
+	for {
+		conn, err := c.connect(ctx)
+		if err == nil {
+			failures = 0
+			handle(ConnectedEvent{Meta: Meta{Type: TypeConnected, Time: now()}})
+			err = c.serve(ctx, conn, handle)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		failures++
+		if c.reconnect.MaxAttempts > 0 && failures >= c.reconnect.MaxAttempts {
+			handle(DisconnectedEvent{Meta: Meta{Type: TypeDisconnected, Time: now()}, Err: err})
+			return err
+		}
+		wait := c.reconnect.Backoff(failures)
+		handle(DisconnectedEvent{Meta: Meta{Type: TypeDisconnected, Time: now()}, Err: err, Retry: wait})
+
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Listen runs the client in the background and delivers its events on the
+// returned channel, which is closed once ctx is done or the client gives
+// up. The caller must keep receiving until then.
+func (c *Client) Listen(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		c.Run(ctx, func(ev Event) {
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return events
+}
+
+// connect dials the server and sends the current subscriptions
+func (c *Client) connect(ctx context.Context) (*websocket.Conn, error) {
+	header := http.Header{}
+	if c.tokens != nil {
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getting token: %w", err)
+		}
+		header.Set("X-Token", token)
+	}
+
+	conn, resp, err := c.dialer.DialContext(ctx, c.url, header)
+	if err != nil {
+		if resp == nil {
+			return nil, fmt.Errorf("dialing %s: %w", c.url, err)
+		}
+		apiErr := &axapi.APIError{
+			HTTPStatus: resp.StatusCode,
+			Method:     http.MethodGet,
+			Endpoint:   endpoint(c.url),
+			Message:    resp.Header.Get("X-Ca-Error-Message"),
+		}
+		if rts, ok := c.tokens.(axapi.RefreshableTokenSource); ok && errors.Is(apiErr, axapi.ErrTokenExpired) {
+			rts.Invalidate()
+		}
+		return nil, apiErr
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn = conn
+	if len(c.topics) > 0 {
+		topics := make([]string, 0, len(c.topics))
+		for t := range c.topics {
+			topics = append(topics, t)
+		}
+		if err := c.writeLocked(c.subscription(OpSubscribe, topics)); err != nil {
+			c.conn = nil
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// serve reads from conn until it fails or ctx is done
+func (c *Client) serve(ctx context.Context, conn *websocket.Conn, handle func(Event)) error {
+	done := make(chan struct{})
+	defer func() {
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+		close(done)
+		conn.Close()
+	}()
+
+	timeout := 2 * c.pingInterval
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(timeout))
+	})
+
+	go func() {
+		ticker := time.NewTicker(c.pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				// unblocks ReadMessage
+				conn.Close()
+				return
+			case <-ticker.C:
+				conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.pingInterval))
+			}
+		}
+	}()
+
+	for {
+		_, frame, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("reading message: %w", err)
+		}
+		conn.SetReadDeadline(time.Now().Add(timeout))
+
+		ev, err := Decode(frame)
+		if ev == nil {
+			ev = RawEvent{Data: frame, Err: err}
+		}
+		handle(ev)
+	}
+}
+
+// endpoint returns the path of u for error messages
+func endpoint(u string) string {
+	if parsed, err := url.Parse(u); err == nil {
+		return parsed.Path
+	}
+	return u
+}
+
+func now() axapi.Time {
+	return axapi.Time{Time: time.Now()}
+}
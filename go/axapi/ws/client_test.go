@@ -0,0 +1,261 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		frame   string
+		want    Event
+		wantErr bool
+	}{
+		{
+			name:  "robot state",
+			frame: `{"topic":"robot/r1/state","type":"robotState","timestamp":1000,"data":{"robotId":"r1","battery":80}}`,
+		},
+		{
+			name:  "task",
+			frame: `{"topic":"task/t1","type":"taskState","data":{"taskId":"t1","robotId":"r1","isExcute":true,"curPtIndex":2}}`,
+			want: TaskEvent{
+				Meta:       Meta{Topic: "task/t1", Type: TypeTaskState},
+				TaskID:     "t1",
+				RobotID:    "r1",
+				IsExcute:   true,
+				PointIndex: 2,
+			},
+		},
+		{
+			name:  "unknown type",
+			frame: `{"topic":"x","type":"other","data":{"a":1}}`,
+			want:  RawEvent{Meta: Meta{Topic: "x", Type: "other"}, Data: []byte(`{"a":1}`)},
+		},
+		{
+			name:    "bad data",
+			frame:   `{"topic":"task/t1","type":"taskState","data":{"curPtIndex":"x"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			frame:   `pong`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode([]byte(tt.frame))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	ev, _ := Decode([]byte(`{"type":"robotState","timestamp":1000,"data":{"robotId":"r1","battery":80}}`))
+	state, ok := ev.(RobotStateEvent)
+	if !ok || state.State.Battery != 80 || state.Time.UnixMilli() != 1000 {
+		t.Errorf("Decode() = %#v, want robot state with battery 80", ev)
+	}
+
+	ev, _ = Decode([]byte(`{"type":"stepAction","data":{"taskId":"t1","action":{"type":40,"data":{"userData":{"cmd":"test"}}}}}`))
+	action, ok := ev.(ActionEvent)
+	if !ok || action.Action.Type != 40 || !reflect.DeepEqual(action.UserData(), map[string]interface{}{"cmd": "test"}) {
+		t.Errorf("Decode() = %#v, want wait action with user data", ev)
+	}
+}
+
+// eventServer accepts WebSocket connections, records the subscriptions it
+// receives and sends frames to the latest connection on demand
+type eventServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	tokens []string
+	subs   chan subscription
+	conns  chan *websocket.Conn
+}
+
+func newEventServer() *eventServer {
+	s := &eventServer{subs: make(chan subscription, 10), conns: make(chan *websocket.Conn, 10)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != DefaultPath {
+			http.NotFound(w, r)
+			return
+		}
+		s.mu.Lock()
+		s.tokens = append(s.tokens, r.Header.Get("X-Token"))
+		s.mu.Unlock()
+		if r.Header.Get("X-Token") == "expired" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.conns <- conn
+		for {
+			var sub subscription
+			if err := conn.ReadJSON(&sub); err != nil {
+				return
+			}
+			s.subs <- sub
+		}
+	}))
+	return s
+}
+
+// rotatingToken returns "expired" until invalidated
+type rotatingToken struct {
+	mu          sync.Mutex
+	invalidated bool
+}
+
+func (r *rotatingToken) Token(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.invalidated {
+		return "fresh", nil
+	}
+	return "expired", nil
+}
+
+func (r *rotatingToken) Invalidate() {
+	r.mu.Lock()
+	r.invalidated = true
+	r.mu.Unlock()
+}
+
+var fastReconnect = axapi.RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+
+func next(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case ev := <-events:
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func TestClient_Listen(t *testing.T) {
+	srv := newEventServer()
+	defer srv.Close()
+
+	api := axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}, axapi.WithTokenSource(&rotatingToken{}))
+	client := NewClient(api, WithReconnectPolicy(fastReconnect))
+	client.Subscribe(RobotTopic("r1"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := client.Listen(ctx)
+
+	// the expired token is rejected and replaced
+	ev := next(t, events)
+	if d, ok := ev.(DisconnectedEvent); !ok || !errors.Is(d.Err, axapi.ErrTokenExpired) {
+		t.Fatalf("first event = %#v, want disconnect with ErrTokenExpired", ev)
+	}
+	if ev := next(t, events); ev.Metadata().Type != TypeConnected {
+		t.Fatalf("event = %#v, want connected", ev)
+	}
+	conn := <-srv.conns
+	if sub := <-srv.subs; !reflect.DeepEqual(sub, subscription{Op: "subscribe", Topics: []string{"robot/r1/state"}}) {
+		t.Errorf("subscription = %+v", sub)
+	}
+
+	client.Subscribe(TaskTopic("t1"))
+	if sub := <-srv.subs; !reflect.DeepEqual(sub.Topics, []string{"task/t1"}) {
+		t.Errorf("live subscription = %+v", sub)
+	}
+
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"topic":"task/t1","type":"taskState","data":{"taskId":"t1","isFinish":true}}`))
+	if ev, ok := next(t, events).(TaskEvent); !ok || !ev.IsFinish || ev.TaskID != "t1" {
+		t.Errorf("event = %#v, want finished task", ev)
+	}
+
+	// dropping the connection triggers a reconnect and a new subscription
+	conn.Close()
+	if ev := next(t, events); ev.Metadata().Type != TypeDisconnected {
+		t.Fatalf("event = %#v, want disconnected", ev)
+	}
+	if ev := next(t, events); ev.Metadata().Type != TypeConnected {
+		t.Fatalf("event = %#v, want connected", ev)
+	}
+	<-srv.conns
+	if sub := <-srv.subs; len(sub.Topics) != 2 {
+		t.Errorf("resubscription = %+v, want both topics", sub)
+	}
+
+	cancel()
+	for range events {
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if want := []string{"expired", "fresh", "fresh"}; !reflect.DeepEqual(srv.tokens, want) {
+		t.Errorf("tokens = %v, want %v", srv.tokens, want)
+	}
+}
+
+func TestClient_SubscriptionFunc(t *testing.T) {
+	srv := newEventServer()
+	defer srv.Close()
+
+	client := NewClient(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}),
+		WithSubscriptionFunc(func(op string, topics []string) interface{} {
+			return map[string]interface{}{"op": op + "-v2", "topics": topics}
+		}))
+	client.Subscribe("custom/r1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := client.Listen(ctx)
+	if ev := next(t, events); ev.Metadata().Type != TypeConnected {
+		t.Fatalf("event = %#v, want connected", ev)
+	}
+	if sub := <-srv.subs; !reflect.DeepEqual(sub, subscription{Op: "subscribe-v2", Topics: []string{"custom/r1"}}) {
+		t.Errorf("subscription = %+v", sub)
+	}
+	client.Unsubscribe("custom/r1")
+	if sub := <-srv.subs; sub.Op != "unsubscribe-v2" {
+		t.Errorf("unsubscription = %+v", sub)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestClient_RunGivesUp(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	policy := fastReconnect
+	policy.MaxAttempts = 3
+	client := NewClient(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL}), WithReconnectPolicy(policy))
+
+	var h Handlers
+	var retries []time.Duration
+	h.Disconnected = func(e DisconnectedEvent) { retries = append(retries, e.Retry) }
+	err := client.Run(context.Background(), h.Handle)
+	if !errors.Is(err, axapi.ErrNotFound) {
+		t.Errorf("Run() error = %v, want ErrNotFound", err)
+	}
+	if len(retries) != 3 || retries[2] != 0 {
+		t.Errorf("retries = %v, want two waits and a final zero", retries)
+	}
+}
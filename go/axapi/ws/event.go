@@ -0,0 +1,220 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+	"github.com/AutoxingTech/APIDemo/go/axapi/robot"
+	"github.com/AutoxingTech/APIDemo/go/axapi/task"
+)
+
+// Message types assumed to be sent by the server; frames of other types
+// are delivered as RawEvent
+const (
+	TypeRobotState = "robotState"
+	TypeTaskState  = "taskState"
+	TypeStepAction = "stepAction"
+)
+
+// Message types generated by the client itself
+const (
+	TypeConnected    = "connected"
+	TypeDisconnected = "disconnected"
+)
+
+// The topic names below are assumed; any topic string can be passed to
+// Client.Subscribe.
+
+// RobotTopic is the topic carrying the state of a robot
+func RobotTopic(robotID string) string {
+	return "robot/" + robotID + "/state"
+}
+
+// RobotTasksTopic is the topic carrying the task and step action events
+// of every task run by a robot
+func RobotTasksTopic(robotID string) string {
+	return "robot/" + robotID + "/task"
+}
+
+// TaskTopic is the topic carrying the events of a single task
+func TaskTopic(taskID string) string {
+	return "task/" + taskID
+}
+
+// message is a frame in the assumed format of the server
+type message struct {
+	Topic string          `json:"topic"`
+	Type  string          `json:"type"`
+	Time  axapi.Time      `json:"timestamp"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Meta is common to all events
+type Meta struct {
+	Topic string     `json:"-"`
+	Type  string     `json:"-"`
+	Time  axapi.Time `json:"-"`
+}
+
+// Metadata returns the meta data of the event
+func (m Meta) Metadata() Meta {
+	return m
+}
+
+// Event is one of RobotStateEvent, TaskEvent, ActionEvent, ConnectedEvent,
+// DisconnectedEvent or RawEvent
+type Event interface {
+	Metadata() Meta
+}
+
+// RobotStateEvent carries a pushed robot state
+type RobotStateEvent struct {
+	Meta
+	State robot.RobotState
+}
+
+// TaskEvent reports a change in the execution of a task
+type TaskEvent struct {
+	Meta
+	TaskID     string `json:"taskId"`
+	RobotID    string `json:"robotId"`
+	IsExcute   bool   `json:"isExcute"`
+	IsFinish   bool   `json:"isFinish"`
+	IsCancel   bool   `json:"isCancel"`
+	PointIndex int    `json:"curPtIndex"`
+}
+
+// Status derives the state of the task from the flags of the event
+func (e TaskEvent) Status() task.Status {
+	return e.Info().Status()
+}
+
+// Info returns the event as a partial TaskInfo, e.g. for
+// task.WaitOptions.Push
+func (e TaskEvent) Info() task.TaskInfo {
+	return task.TaskInfo{
+		Task:         task.Task{RobotID: e.RobotID},
+		TaskID:       e.TaskID,
+		IsExcute:     e.IsExcute,
+		IsFinish:     e.IsFinish,
+		IsCancel:     e.IsCancel,
+		CurrentIndex: e.PointIndex,
+	}
+}
+
+// ActionEvent is sent when a task reaches a step action that reports back,
+// such as the one built by task.Action.WaitAction
+type ActionEvent struct {
+	Meta
+	TaskID     string          `json:"taskId"`
+	RobotID    string          `json:"robotId"`
+	PointIndex int             `json:"ptIndex"`
+	Action     task.ActionType `json:"action"`
+}
+
+// UserData returns the userData of a wait action, or nil
+func (e ActionEvent) UserData() interface{} {
+	return e.Action.Data["userData"]
+}
+
+// ConnectedEvent is delivered each time the connection is (re)established
+// and the subscriptions have been sent
+type ConnectedEvent struct {
+	Meta
+}
+
+// DisconnectedEvent is delivered when the connection is lost or cannot be
+// established. Retry is the wait before the next attempt; it is zero when
+// the client gives up.
+type DisconnectedEvent struct {
+	Meta
+	Err   error
+	Retry time.Duration
+}
+
+// RawEvent carries a message of an unknown type, or one whose data could
+// not be decoded, in which case Err is set
+type RawEvent struct {
+	Meta
+	Data json.RawMessage
+	Err  error
+}
+
+// Decode parses a frame sent by the server into a typed event
+func Decode(frame []byte) (Event, error) {
+	var msg message
+	if err := json.Unmarshal(frame, &msg); err != nil {
+		return nil, fmt.Errorf("parsing message: %w", err)
+	}
+	meta := Meta{Topic: msg.Topic, Type: msg.Type, Time: msg.Time}
+
+	var ev Event
+	var err error
+	switch msg.Type {
+	case TypeRobotState:
+		e := RobotStateEvent{Meta: meta}
+		err = json.Unmarshal(msg.Data, &e.State)
+		ev = e
+	case TypeTaskState:
+		e := TaskEvent{}
+		err = json.Unmarshal(msg.Data, &e)
+		e.Meta = meta
+		ev = e
+	case TypeStepAction:
+		e := ActionEvent{}
+		err = json.Unmarshal(msg.Data, &e)
+		e.Meta = meta
+		ev = e
+	default:
+		return RawEvent{Meta: meta, Data: msg.Data}, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("parsing %s data: %w", msg.Type, err)
+		return RawEvent{Meta: meta, Data: msg.Data, Err: err}, err
+	}
+	return ev, nil
+}
+
+// Handlers dispatches events to per-type callbacks; nil callbacks are
+// skipped. Pass its Handle method to Client.Run.
+type Handlers struct {
+	RobotState   func(RobotStateEvent)
+	Task         func(TaskEvent)
+	Action       func(ActionEvent)
+	Connected    func(ConnectedEvent)
+	Disconnected func(DisconnectedEvent)
+	// Other receives RawEvents
+	Other func(Event)
+}
+
+// Handle calls the callback matching the type of ev
+func (h Handlers) Handle(ev Event) {
+	switch e := ev.(type) {
+	case RobotStateEvent:
+		if h.RobotState != nil {
+			h.RobotState(e)
+		}
+	case TaskEvent:
+		if h.Task != nil {
+			h.Task(e)
+		}
+	case ActionEvent:
+		if h.Action != nil {
+			h.Action(e)
+		}
+	case ConnectedEvent:
+		if h.Connected != nil {
+			h.Connected(e)
+		}
+	case DisconnectedEvent:
+		if h.Disconnected != nil {
+			h.Disconnected(e)
+		}
+	default:
+		if h.Other != nil {
+			h.Other(ev)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi/task"
+)
+
+// Waiting is a task held at a step action, handed to an ActionHandler so it
+// can decide what the task does next
+type Waiting struct {
+	Event ActionEvent
+	tasks *task.TaskManager
+}
+
+// Resume lets the task continue to its next point
+func (w *Waiting) Resume(ctx context.Context) error {
+	_, err := w.tasks.GoNextPoint(ctx, w.Event.TaskID)
+	return err
+}
+
+// Cancel cancels the task
+func (w *Waiting) Cancel(ctx context.Context) error {
+	_, err := w.tasks.CancelTask(ctx, w.Event.TaskID)
+	return err
+}
+
+// Redirect cancels the task and sends the robot on t instead. It returns
+// the ID of the new task. Nothing is cancelled if t fails validation.
+func (w *Waiting) Redirect(ctx context.Context, t *task.Task) (string, error) {
+	// keep the current task if the new one would be rejected
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+	if err := w.Cancel(ctx); err != nil {
+		return "", err
+	}
+	id, err := w.tasks.NewTask(ctx, t)
+	if err != nil {
+		return "", err
+	}
+	return id, w.tasks.ExecuteTask(ctx, id)
+}
+
+// ActionHandler handles a step action event. Returning without calling
+// Resume, Cancel or Redirect leaves the task waiting.
+type ActionHandler func(ctx context.Context, w *Waiting) error
+
+type route struct {
+	match   map[string]interface{}
+	handler ActionHandler
+}
+
+// Router dispatches step action events to handlers by the fields of their
+// userData, e.g. the {"cmd":"test"} given to task.Action.WaitAction
+type Router struct {
+	tasks *task.TaskManager
+
+	mu       sync.RWMutex
+	routes   []route
+	fallback ActionHandler
+
+	// OnError, if set, receives the errors of handlers run by Handler
+	OnError func(ev ActionEvent, err error)
+}
+
+// NewRouter creates a router whose handlers control tasks through tasks
+func NewRouter(tasks *task.TaskManager) *Router {
+	return &Router{tasks: tasks}
+}
+
+// Handle registers h for events whose userData is an object containing all
+// fields of match with equal values. Routes are tried in the order they
+// were registered and the first match wins.
+func (r *Router) Handle(match map[string]interface{}, h ActionHandler) {
+	// compare against match as it would decode from JSON, so that e.g.
+	// an int matches the float64 in the event
+	var normalized map[string]interface{}
+	data, err := json.Marshal(match)
+	if err != nil || json.Unmarshal(data, &normalized) != nil {
+		panic(fmt.Sprintf("ws: userData match %v is not JSON: %v", match, err))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, route{normalized, h})
+}
+
+// HandleDefault registers h for events no route matches
+func (r *Router) HandleDefault(h ActionHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = h
+}
+
+// Dispatch runs the handler matching ev. Events without a matching route
+// and without a default handler are ignored.
+func (r *Router) Dispatch(ctx context.Context, ev ActionEvent) error {
+	h := r.lookup(ev.UserData())
+	if h == nil {
+		return nil
+	}
+	return h(ctx, &Waiting{Event: ev, tasks: r.tasks})
+}
+
+// lookup returns the handler for userData, or nil
+func (r *Router) lookup(userData interface{}) ActionHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if fields, ok := userData.(map[string]interface{}); ok {
+		for _, rt := range r.routes {
+			if matches(fields, rt.match) {
+				return rt.handler
+			}
+		}
+	}
+	return r.fallback
+}
+
+// matches reports whether fields contains every entry of match
+func matches(fields, match map[string]interface{}) bool {
+	for k, want := range match {
+		got, ok := fields[k]
+		if !ok || !reflect.DeepEqual(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// Handler returns a callback for Handlers.Action that dispatches every
+// event in its own goroutine, so the API calls made by handlers do not
+// hold up the read loop. Errors are passed to OnError.
+func (r *Router) Handler(ctx context.Context) func(ActionEvent) {
+	return func(ev ActionEvent) {
+		go func() {
+			if err := r.Dispatch(ctx, ev); err != nil && r.OnError != nil {
+				r.OnError(ev, err)
+			}
+		}()
+	}
+}
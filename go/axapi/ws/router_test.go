@@ -0,0 +1,108 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+	"github.com/AutoxingTech/APIDemo/go/axapi/task"
+)
+
+// taskServer records the paths of the task API calls it receives
+func taskServer(paths *[]string) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*paths = append(*paths, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/task/v1.1" {
+			w.Write([]byte(`{"status":200,"data":{"taskId":"t2"}}`))
+			return
+		}
+		w.Write([]byte(`{"status":200}`))
+	}))
+}
+
+func waitEvent(userData interface{}) ActionEvent {
+	return ActionEvent{TaskID: "t1", Action: task.Action.WaitAction(userData)}
+}
+
+func TestRouter_Dispatch(t *testing.T) {
+	var paths []string
+	srv := taskServer(&paths)
+	defer srv.Close()
+
+	router := NewRouter(task.NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL})))
+	router.Handle(map[string]interface{}{"cmd": "test"}, func(ctx context.Context, w *Waiting) error {
+		return w.Resume(ctx)
+	})
+	router.Handle(map[string]interface{}{"cmd": "abort", "code": 1}, func(ctx context.Context, w *Waiting) error {
+		return w.Cancel(ctx)
+	})
+	errUnknown := errors.New("unknown command")
+	router.HandleDefault(func(ctx context.Context, w *Waiting) error {
+		return errUnknown
+	})
+
+	tests := []struct {
+		name     string
+		userData interface{}
+		wantPath string
+		wantErr  error
+	}{
+		{name: "resume", userData: map[string]interface{}{"cmd": "test", "extra": true}, wantPath: "POST /task/v1.1/t1/goNext"},
+		{name: "cancel", userData: map[string]interface{}{"cmd": "abort", "code": 1.0}, wantPath: "POST /task/v1.1/t1/cancel"},
+		{name: "partial match", userData: map[string]interface{}{"cmd": "abort"}, wantErr: errUnknown},
+		{name: "not an object", userData: "test", wantErr: errUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths = nil
+			err := router.Dispatch(context.Background(), waitEvent(tt.userData))
+			if err != tt.wantErr {
+				t.Fatalf("Dispatch() error = %v, want %v", err, tt.wantErr)
+			}
+			var want []string
+			if tt.wantPath != "" {
+				want = []string{tt.wantPath}
+			}
+			if !reflect.DeepEqual(paths, want) {
+				t.Errorf("calls = %v, want %v", paths, want)
+			}
+		})
+	}
+}
+
+func TestWaiting_Redirect(t *testing.T) {
+	var paths []string
+	srv := taskServer(&paths)
+	defer srv.Close()
+
+	router := NewRouter(task.NewTaskManager(axapi.NewClient(&axapi.Config{URLPrefix: srv.URL})))
+	done := make(chan string, 1)
+	router.Handle(map[string]interface{}{"cmd": "elsewhere"}, func(ctx context.Context, w *Waiting) error {
+		b := task.NewTaskBuilder("redirect", "r1")
+		b.AddTaskPt(task.NewTaskPoint(task.POI{AreaID: "a1", Coordinate: []float64{1, 2}}, true))
+		if _, err := w.Redirect(ctx, task.NewTaskBuilder("empty", "r1").GetTask()); !errors.Is(err, task.ErrInvalidTask) {
+			t.Errorf("Redirect() to an empty task error = %v, want ErrInvalidTask", err)
+		}
+		id, err := w.Redirect(ctx, b.GetTask())
+		done <- id
+		return err
+	})
+	router.OnError = func(ev ActionEvent, err error) { t.Errorf("handler error = %v", err) }
+
+	router.Handler(context.Background())(waitEvent(map[string]interface{}{"cmd": "elsewhere"}))
+	if id := <-done; id != "t2" {
+		t.Errorf("Redirect() = %q, want t2", id)
+	}
+	want := []string{"POST /task/v1.1/t1/cancel", "POST /task/v1.1", "POST /task/v1.1/t2/execute"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("calls = %v, want %v", paths, want)
+	}
+}
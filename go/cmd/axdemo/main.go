@@ -0,0 +1,124 @@
+// Command axdemo walks through the typical Autoxing API workflow:
+// obtain a token, list robots, look up POIs and run a simple task.
+//
+// Configuration is read from the -config file, the URL_PREFIX, REGION,
+// APP_ID, APP_SECRET, Authorization and RobotID environment variables and
+// the command-line flags, later sources taking precedence.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/AutoxingTech/APIDemo/go/axapi"
+	"github.com/AutoxingTech/APIDemo/go/axapi/auth"
+	"github.com/AutoxingTech/APIDemo/go/axapi/mapinfo"
+	"github.com/AutoxingTech/APIDemo/go/axapi/robot"
+	"github.com/AutoxingTech/APIDemo/go/axapi/task"
+)
+
+func main() {
+	configFlags := axapi.RegisterConfigFlags(flag.CommandLine)
+	runTask := flag.Bool("run-task", false, "create and execute a task visiting the robot's first two POIs")
+	wait := flag.Bool("wait", false, "with -run-task, wait for the task to end and report its progress")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	config, err := configFlags.Load()
+	if err != nil {
+		fmt.Println("Invalid configuration:", err)
+		os.Exit(2)
+	}
+
+	client := axapi.NewClient(config)
+	tokenManager := auth.NewTokenManager(client)
+	_, err = tokenManager.GetToken(ctx)
+	if err != nil {
+		fmt.Println("Get Token Failed:", err)
+		os.Exit(1)
+	}
+	api := client.WithTokenSource(tokenManager)
+
+	robotManager := robot.NewRobotManager(api)
+	robots, err := robotManager.ListAll(ctx, nil)
+	if err != nil {
+		fmt.Println("Get Robot List Failed:", err)
+		os.Exit(1)
+	}
+	for _, r := range robots {
+		fmt.Printf("Robot ID: %s (%s), Model: %s, Online: %v\n", r.RobotID, r.DisplayName(), r.Model, r.IsOnLine)
+	}
+
+	if config.RobotID == "" {
+		return
+	}
+
+	state, err := robotManager.GetRobotState(ctx, config.RobotID)
+	if err != nil {
+		fmt.Println("Get Robot State Failed:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Battery: %d%% (charging %v), Pose: %+v, Idle: %v, Fault: %v\n",
+		state.Battery, state.IsCharging(), state.Pose, state.IsIdle(), state.HasFault())
+
+	mapManager := mapinfo.NewMapInfoManager(api)
+	pois, err := mapManager.GetPoiList(ctx, "", config.RobotID, "")
+	if err != nil {
+		fmt.Println("Get Poi List Failed:", err)
+		os.Exit(1)
+	}
+	for _, poi := range pois {
+		fmt.Printf("POI: %s (%s) %v\n", poi.Name, poi.AreaID, poi.Coordinate)
+	}
+
+	if !*runTask {
+		return
+	}
+	if len(pois) < 2 {
+		fmt.Println("At least two POIs are required to run the demo task")
+		os.Exit(1)
+	}
+
+	builder := task.NewTaskBuilder("Demo", config.RobotID)
+	builder.AddTaskPt(task.NewTaskPoint(pois[0], true))
+	builder.AddTaskPt(task.NewTaskPoint(pois[1], true).
+		AddStepActs(task.Action.PauseAction(10)))
+	builder.SetBackPt(task.NewTaskPoint(pois[0], true))
+
+	demoTask, err := builder.Build()
+	if err != nil {
+		fmt.Println("Invalid Task:", err)
+		os.Exit(1)
+	}
+
+	taskManager := task.NewTaskManager(api)
+	taskID, err := taskManager.NewTask(ctx, demoTask)
+	if err != nil {
+		fmt.Println("New Task Failed:", err)
+		os.Exit(1)
+	}
+	if err := taskManager.ExecuteTask(ctx, taskID); err != nil {
+		fmt.Println("Execute Task Failed:", err)
+		os.Exit(1)
+	}
+	fmt.Println("Task executing:", taskID)
+	if !*wait {
+		return
+	}
+
+	info, err := taskManager.WaitForTask(ctx, taskID, &task.WaitOptions{
+		OnProgress: func(info task.TaskInfo, index int) {
+			fmt.Printf("Reached point %d/%d\n", index+1, len(info.TaskPts))
+		},
+	})
+	if err != nil {
+		fmt.Println("Wait Task Failed:", err)
+		os.Exit(1)
+	}
+	fmt.Println("Task", info.Status())
+}